@@ -3,7 +3,9 @@ package jsonry_test
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"code.cloudfoundry.org/jsonry"
@@ -67,6 +69,45 @@ func BenchmarkUnmarshal(b *testing.B) {
 	})
 }
 
+func BenchmarkUnmarshalList(b *testing.B) {
+	type element struct {
+		GUID      string `jsonry:"metadata.guid"`
+		SpaceName string
+		Instances int
+	}
+
+	elements := make([]string, 1000)
+	for i := range elements {
+		elements[i] = fmt.Sprintf(`{"metadata":{"guid":"guid-%d"},"SpaceName":"a","space_name":"b","Instances":1,"instances":2}`, i)
+	}
+	data := []byte("[" + strings.Join(elements, ",") + "]")
+
+	b.Run("JSONry", func(b *testing.B) {
+		var receiver []element
+		for i := 0; i < b.N; i++ {
+			jsonry.Unmarshal(data, &receiver)
+		}
+
+		b.StopTimer()
+		if len(receiver) != 1000 || receiver[999].SpaceName != "a" {
+			b.Fatalf("received list not equal")
+		}
+	})
+
+	b.Run("JSONry with Naming", func(b *testing.B) {
+		config := jsonry.Config{Naming: jsonry.SnakeCase}
+		var receiver []element
+		for i := 0; i < b.N; i++ {
+			config.Unmarshal(data, &receiver)
+		}
+
+		b.StopTimer()
+		if len(receiver) != 1000 || receiver[999].SpaceName != "b" {
+			b.Fatalf("received list not equal")
+		}
+	})
+}
+
 func BenchmarkMarshal(b *testing.B) {
 	b.Run("JSONry", func(b *testing.B) {
 		u := unmarshaled()
@@ -0,0 +1,69 @@
+package jsonry
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+type byteEncoding int
+
+const (
+	base64Encoding byteEncoding = iota
+	base64URLEncoding
+	hexEncoding
+)
+
+func (e byteEncoding) encode(b []byte) string {
+	switch e {
+	case hexEncoding:
+		return hex.EncodeToString(b)
+	case base64URLEncoding:
+		return base64.URLEncoding.EncodeToString(b)
+	default:
+		return base64.StdEncoding.EncodeToString(b)
+	}
+}
+
+func (e byteEncoding) decode(s string) ([]byte, error) {
+	switch e {
+	case hexEncoding:
+		return hex.DecodeString(s)
+	case base64URLEncoding:
+		return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	default:
+		return base64.StdEncoding.DecodeString(s)
+	}
+}
+
+// byteSlice identifies a slice that is represented as a string in the same way as encoding/json,
+// which excludes slices where the element type has custom JSON marshaling
+func byteSlice(t reflect.Type) bool {
+	if t.Kind() != reflect.Slice || t.Elem().Kind() != reflect.Uint8 {
+		return false
+	}
+
+	m := reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	return !t.Elem().Implements(m) && !reflect.PointerTo(t.Elem()).Implements(m)
+}
+
+func marshalBytes(in reflect.Value, opts tagOptions) interface{} {
+	if in.IsNil() {
+		return nil
+	}
+
+	return opts.bytes.encode(in.Bytes())
+}
+
+func (c *Config) unmarshalBytes(target reflect.Value, source string, opts tagOptions) error {
+	b, err := opts.bytes.decode(source)
+	if err != nil {
+		return newConversionError(source)
+	}
+
+	// SetBytes() also works when the element type is a named byte type, which cannot be converted
+	c.allocateIfNeeded(target).SetBytes(b)
+	return nil
+}
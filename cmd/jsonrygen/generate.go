@@ -0,0 +1,502 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"go/types"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/jsonry/internal/path"
+)
+
+const (
+	jsonryPackage = "code.cloudfoundry.org/jsonry"
+	genPackage    = "code.cloudfoundry.org/jsonry/gen"
+	remainOption  = "remain"
+)
+
+// structType describes how the methods for a struct type are generated
+type structType struct {
+	name     string
+	receiver string
+	fields   []*field
+	members  []*member
+
+	// marshalFallback and unmarshalFallback are the reasons for using jsonry.Marshal() and
+	// jsonry.Unmarshal() for the whole struct, when the methods cannot produce the same results
+	marshalFallback   string
+	unmarshalFallback string
+}
+
+// field is a public field of the struct, which has an index in the gen.Plan
+type field struct {
+	index  int
+	name   string
+	tag    string
+	typ    types.Type
+	path   path.Path
+	spread bool
+}
+
+// member is a key in the JSON object. It either has a field, or has members.
+type member struct {
+	name    string
+	field   *field
+	members []*member
+}
+
+func generate(pkg *types.Package, names []string, command string) ([]byte, error) {
+	var structs []*structType
+	for _, name := range names {
+		s, err := newStructType(pkg, name)
+		if err != nil {
+			return nil, err
+		}
+		structs = append(structs, s)
+	}
+
+	g := &generator{}
+	g.printf("// Code generated by %q; DO NOT EDIT.\n\n", command)
+	g.printf("package %s\n\n", pkg.Name())
+	g.imports(structs)
+
+	for _, s := range structs {
+		g.plan(s)
+		g.marshal(s)
+		g.unmarshal(s)
+	}
+
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error formatting generated code: %w\n%s", err, g.buf.Bytes())
+	}
+
+	return src, nil
+}
+
+func newStructType(pkg *types.Package, name string) (*structType, error) {
+	obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("type %q not found in package %q", name, pkg.Name())
+	}
+
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return nil, fmt.Errorf("type %q must be a defined type", name)
+	}
+
+	st, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return nil, fmt.Errorf("type %q is not a struct type", name)
+	}
+
+	s := &structType{name: name, receiver: receiver(named)}
+
+	for i := 0; i < st.NumFields(); i++ {
+		v := st.Field(i)
+		if !v.Exported() {
+			continue
+		}
+
+		f := &field{
+			index: len(s.fields),
+			name:  v.Name(),
+			tag:   st.Tag(i),
+			typ:   v.Type(),
+		}
+		f.path = path.ComputePath(reflect.StructField{Name: f.name, Tag: reflect.StructTag(f.tag)})
+
+		switch {
+		case f.path.Option(remainOption):
+			s.marshalFallback = `it has a ",remain" field`
+			s.unmarshalFallback = s.marshalFallback
+			continue
+		case isTypeParam(f.typ):
+			s.marshalFallback = fmt.Sprintf("the type of field %q is a type parameter", f.name)
+			s.unmarshalFallback = s.marshalFallback
+		case mayMarshalJSONry(f.typ):
+			s.marshalFallback = fmt.Sprintf("field %q may implement jsonry.JSONryMarshaler", f.name)
+		}
+
+		switch {
+		case mayUnmarshalJSONry(f.typ):
+			s.unmarshalFallback = fmt.Sprintf("field %q may implement jsonry.JSONryUnmarshaler", f.name)
+		case f.path.HasDefault:
+			s.unmarshalFallback = fmt.Sprintf("field %q has a default value", f.name)
+		}
+
+		s.fields = append(s.fields, f)
+
+		if !f.path.OmitAlways && !s.add(f) {
+			s.marshalFallback = fmt.Sprintf("the path of field %q conflicts with another field", f.name)
+		}
+	}
+
+	sortMembers(s.members)
+	return s, nil
+}
+
+func receiver(named *types.Named) string {
+	params := named.TypeParams()
+	if params.Len() == 0 {
+		return named.Obj().Name()
+	}
+
+	var names []string
+	for i := 0; i < params.Len(); i++ {
+		names = append(names, params.At(i).Obj().Name())
+	}
+	return fmt.Sprintf("%s[%s]", named.Obj().Name(), strings.Join(names, ", "))
+}
+
+// add inserts the field into the members, and reports false when the path conflicts with another
+// field, so that the output would depend on the order in which the fields are written
+func (s *structType) add(f *field) bool {
+	members := &s.members
+	rest := f.path
+
+	for {
+		segment, stem := rest.Pull()
+		var existing *member
+		for _, m := range *members {
+			if m.name == segment.Name {
+				existing = m
+			}
+		}
+
+		switch {
+		case stem.Len() == 0 || segment.List:
+			if existing != nil {
+				return false
+			}
+			f.spread = stem.Len() > 0
+			*members = append(*members, &member{name: segment.Name, field: f})
+			return true
+		case existing == nil:
+			existing = &member{name: segment.Name}
+			*members = append(*members, existing)
+		case existing.field != nil:
+			return false
+		}
+
+		members = &existing.members
+		rest = stem
+	}
+}
+
+func sortMembers(members []*member) {
+	sort.Slice(members, func(i, j int) bool { return members[i].name < members[j].name })
+	for _, m := range members {
+		sortMembers(m.members)
+	}
+}
+
+type generator struct {
+	buf bytes.Buffer
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) imports(structs []*structType) {
+	var usesGen, usesJSONry bool
+	for _, s := range structs {
+		if s.marshalFallback == "" || s.unmarshalFallback == "" {
+			usesGen = true
+		}
+		if s.marshalFallback != "" || s.unmarshalFallback != "" {
+			usesJSONry = true
+		}
+	}
+
+	g.printf("import (\n")
+	if usesJSONry {
+		g.printf("%q\n", jsonryPackage)
+	}
+	if usesGen {
+		g.printf("%q\n", genPackage)
+	}
+	g.printf(")\n\n")
+}
+
+func (g *generator) plan(s *structType) {
+	if s.marshalFallback != "" && s.unmarshalFallback != "" {
+		return
+	}
+
+	g.printf("var %s = gen.NewPlan(\n", planName(s))
+	for _, f := range s.fields {
+		if f.tag == "" {
+			g.printf("gen.Field{Name: %q},\n", f.name)
+		} else {
+			g.printf("gen.Field{Name: %q, Tag: %s},\n", f.name, quote(f.tag))
+		}
+	}
+	g.printf(")\n\n")
+}
+
+func (g *generator) marshal(s *structType) {
+	if s.marshalFallback != "" {
+		g.printf("// MarshalJSON converts the %s into JSON using jsonry.Marshal(), because %s\n", s.name, s.marshalFallback)
+		g.printf("func (x %s) MarshalJSON() ([]byte, error) {\n", s.receiver)
+		g.printf("return jsonry.Marshal(x)\n")
+		g.printf("}\n\n")
+		return
+	}
+
+	g.printf("// MarshalJSON converts the %s into JSON in the same way as jsonry.Marshal()\n", s.name)
+	g.printf("func (x %s) MarshalJSON() ([]byte, error) {\n", s.receiver)
+	g.printf("w := %s.Writer()\n", planName(s))
+	g.members(s.members)
+	g.printf("return w.Bytes()\n")
+	g.printf("}\n\n")
+}
+
+func (g *generator) members(members []*member) {
+	for _, m := range members {
+		if m.field == nil {
+			g.printf("w.Object(%q)\n", m.name)
+			g.members(m.members)
+			g.printf("w.End()\n")
+			continue
+		}
+
+		f := m.field
+		cond := condition(f)
+		if cond != "" {
+			g.printf("if %s {\n", cond)
+		}
+
+		g.printf("w.Key(%q)\n", m.name)
+		switch method, conversion := writeMethod(f.typ); {
+		case f.spread:
+			g.printf("w.Spread(%d, &x.%s)\n", f.index, f.name)
+		case method != "":
+			g.printf("w.%s(%s)\n", method, convert(conversion, f))
+		default:
+			g.printf("w.Value(%d, &x.%s)\n", f.index, f.name)
+		}
+
+		if cond != "" {
+			g.printf("}\n")
+		}
+	}
+}
+
+func (g *generator) unmarshal(s *structType) {
+	if s.unmarshalFallback != "" {
+		g.printf("// UnmarshalJSON sets the %s from JSON using jsonry.Unmarshal(), because %s\n", s.name, s.unmarshalFallback)
+		g.printf("func (x *%s) UnmarshalJSON(data []byte) error {\n", s.receiver)
+		g.printf("return jsonry.Unmarshal(data, x)\n")
+		g.printf("}\n\n")
+		return
+	}
+
+	g.printf("// UnmarshalJSON sets the %s from JSON in the same way as jsonry.Unmarshal()\n", s.name)
+	g.printf("func (x *%s) UnmarshalJSON(data []byte) error {\n", s.receiver)
+	g.printf("r, err := %s.Read(data)\n", planName(s))
+	g.printf("if err != nil {\nreturn err\n}\n\n")
+	for _, f := range s.fields {
+		if function := readFunction(f.typ); function != "" {
+			g.printf("gen.%s(r, %d, &x.%s)\n", function, f.index, f.name)
+		} else {
+			g.printf("r.Value(%d, &x.%s)\n", f.index, f.name)
+		}
+	}
+	g.printf("return r.Err()\n")
+	g.printf("}\n\n")
+}
+
+func planName(s *structType) string {
+	return "jsonry" + s.name
+}
+
+func quote(s string) string {
+	if strconv.CanBackquote(s) {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
+
+func convert(conversion string, f *field) string {
+	if conversion == "" {
+		return "x." + f.name
+	}
+	return fmt.Sprintf("%s(x.%s)", conversion, f.name)
+}
+
+// condition is the Go expression for whether a field is marshaled, following the same rules as jsonry.Marshal()
+func condition(f *field) string {
+	x := "x." + f.name
+	omitEmpty := f.path.OmitEmpty
+
+	// OmitJSONry() is only called for a nil pointer when it has a pointer receiver
+	if p, ok := f.typ.Underlying().(*types.Pointer); ok {
+		switch {
+		case implementsOmissible(p.Elem()) && omitEmpty:
+			return fmt.Sprintf("%s != nil && !%s.OmitJSONry()", x, x)
+		case implementsOmissible(p.Elem()):
+			return fmt.Sprintf("%s == nil || !%s.OmitJSONry()", x, x)
+		case implementsOmissible(f.typ):
+			return fmt.Sprintf("!%s.OmitJSONry()", x)
+		case omitEmpty:
+			return x + " != nil"
+		default:
+			return ""
+		}
+	}
+
+	switch {
+	case implementsOmissible(f.typ):
+		return fmt.Sprintf("!%s.OmitJSONry()", x)
+	case !omitEmpty:
+		return ""
+	}
+
+	switch u := f.typ.Underlying().(type) {
+	case *types.Interface:
+		return x + " != nil"
+	case *types.Slice, *types.Map, *types.Array:
+		return fmt.Sprintf("len(%s) != 0", x)
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsString != 0:
+			return x + ` != ""`
+		case u.Info()&types.IsBoolean != 0:
+			return x
+		case u.Info()&types.IsNumeric != 0 && u.Kind() != types.Uintptr:
+			return x + " != 0"
+		}
+	}
+
+	return ""
+}
+
+// writeMethod returns the gen.Writer method that writes a value of the type directly, and the
+// conversion needed for the argument. Types with methods are written by w.Value(), since they
+// may implement one of the interfaces that change how they are marshaled.
+func writeMethod(t types.Type) (method, conversion string) {
+	b, ok := basic(t)
+	if !ok {
+		return "", ""
+	}
+
+	switch b.Kind() {
+	case types.String:
+		method, conversion = "String", "string"
+	case types.Bool:
+		method, conversion = "Bool", "bool"
+	case types.Int, types.Int8, types.Int16, types.Int32, types.Int64:
+		method, conversion = "Int", "int64"
+	case types.Uint, types.Uint8, types.Uint16, types.Uint32, types.Uint64:
+		method, conversion = "Uint", "uint64"
+	case types.Float32:
+		method, conversion = "Float32", "float32"
+	case types.Float64:
+		method, conversion = "Float64", "float64"
+	default:
+		return "", ""
+	}
+
+	if types.Identical(t, types.Universe.Lookup(conversion).Type()) {
+		conversion = ""
+	}
+
+	return method, conversion
+}
+
+// readFunction returns the gen function that sets a field of the type directly
+func readFunction(t types.Type) string {
+	b, ok := basic(t)
+	if !ok {
+		return ""
+	}
+
+	switch b.Kind() {
+	case types.String:
+		return "String"
+	case types.Bool:
+		return "Bool"
+	case types.Int, types.Int8, types.Int16, types.Int32, types.Int64:
+		return "Int"
+	case types.Uint, types.Uint8, types.Uint16, types.Uint32, types.Uint64:
+		return "Uint"
+	case types.Float32, types.Float64:
+		return "Float"
+	default:
+		return ""
+	}
+}
+
+// basic returns the underlying basic type when the type has no methods
+func basic(t types.Type) (*types.Basic, bool) {
+	b, ok := t.Underlying().(*types.Basic)
+	if !ok || types.NewMethodSet(types.NewPointer(t)).Len() > 0 {
+		return nil, false
+	}
+	return b, true
+}
+
+func isTypeParam(t types.Type) bool {
+	_, ok := t.(*types.TypeParam)
+	return ok
+}
+
+func hasMethod(t types.Type, name string) bool {
+	return types.NewMethodSet(t).Lookup(nil, name) != nil
+}
+
+var omissible = types.NewInterfaceType([]*types.Func{
+	types.NewFunc(token.NoPos, nil, "OmitJSONry", types.NewSignatureType(nil, nil, nil, nil,
+		types.NewTuple(types.NewVar(token.NoPos, nil, "", types.Typ[types.Bool])), false)),
+}, nil).Complete()
+
+func implementsOmissible(t types.Type) bool {
+	return types.Implements(t, omissible)
+}
+
+// mayMarshalJSONry identifies types where a JSONryMarshaler may be called, including when
+// the type is an Optional that holds a JSONryMarshaler
+func mayMarshalJSONry(t types.Type) bool {
+	t = elem(t)
+	if hasMethod(t, "MarshalJSONry") {
+		return true
+	}
+	if arg, ok := optional(t); ok {
+		return mayMarshalJSONry(arg)
+	}
+	return false
+}
+
+func mayUnmarshalJSONry(t types.Type) bool {
+	t = elem(t)
+	if hasMethod(types.NewPointer(t), "UnmarshalJSONry") {
+		return true
+	}
+	if arg, ok := optional(t); ok {
+		return mayUnmarshalJSONry(arg)
+	}
+	return false
+}
+
+func elem(t types.Type) types.Type {
+	if p, ok := t.Underlying().(*types.Pointer); ok {
+		return p.Elem()
+	}
+	return t
+}
+
+// optional returns the type argument when the type is a jsonry.Optional
+func optional(t types.Type) (types.Type, bool) {
+	n, ok := t.(*types.Named)
+	if !ok || n.Obj().Pkg() == nil || n.Obj().Pkg().Path() != jsonryPackage || n.Obj().Name() != "Optional" {
+		return nil, false
+	}
+	return n.TypeArgs().At(0), true
+}
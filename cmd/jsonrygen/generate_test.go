@@ -0,0 +1,68 @@
+package main
+
+import (
+	"go/types"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("generate", func() {
+	const (
+		dir    = "internal/example"
+		output = "example_jsonry.go"
+	)
+
+	var pkg *types.Package
+
+	BeforeEach(func() {
+		var err error
+		pkg, err = load(dir, output)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("generates the code in the example package", func() {
+		names := []string{"App", "Process", "Scalars", "OmitEmpty", "Lists", "Remain", "Conflict", "Default", "Page"}
+		src, err := generate(pkg, names, "jsonrygen -type=App,Process,Scalars,OmitEmpty,Lists,Remain,Conflict,Default,Page -output=example_jsonry.go")
+		Expect(err).NotTo(HaveOccurred())
+
+		expected, err := os.ReadFile(filepath.Join(dir, output))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(src)).To(Equal(string(expected)), "the example package must be generated again")
+	})
+
+	It("generates the code in the tables package", func() {
+		const (
+			dir    = "internal/tables"
+			output = "tables_jsonry.go"
+		)
+
+		pkg, err := load(dir, output)
+		Expect(err).NotTo(HaveOccurred())
+
+		expected, err := os.ReadFile(filepath.Join(dir, output))
+		Expect(err).NotTo(HaveOccurred())
+
+		// the tables package has too many types to list here, so they are read from the generated code
+		command := regexp.MustCompile(`^// Code generated by "(jsonrygen -type=(\S+) -output=tables_jsonry.go)"`).FindStringSubmatch(string(expected))
+		Expect(command).To(HaveLen(3))
+
+		src, err := generate(pkg, strings.Split(command[2], ","), command[1])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(src)).To(Equal(string(expected)), "the tables package must be generated again")
+	})
+
+	It("fails when the type does not exist", func() {
+		_, err := generate(pkg, []string{"Missing"}, "jsonrygen")
+		Expect(err).To(MatchError(`type "Missing" not found in package "example"`))
+	})
+
+	It("fails when the type is not a struct", func() {
+		_, err := generate(pkg, []string{"State"}, "jsonrygen")
+		Expect(err).To(MatchError(`type "State" is not a struct type`))
+	})
+})
@@ -0,0 +1,97 @@
+// Package example has struct types with generated methods, which are tested against the reflection based
+// implementation in the jsonry package
+package example
+
+import (
+	"encoding/json"
+	"time"
+
+	"code.cloudfoundry.org/jsonry"
+)
+
+//go:generate go run code.cloudfoundry.org/jsonry/cmd/jsonrygen -type=App,Process,Scalars,OmitEmpty,Lists,Remain,Conflict,Default,Page -output=example_jsonry.go
+
+type App struct {
+	GUID      string            `jsonry:"guid"`
+	Name      string            `jsonry:"name"`
+	SpaceGUID string            `jsonry:"relationships.space.data.guid"`
+	OrgGUID   string            `jsonry:"relationships.space.data.org_guid,omitempty"`
+	State     State             `jsonry:"state"`
+	Processes []Process         `jsonry:"processes"`
+	Labels    map[string]string `jsonry:"metadata.labels,omitempty"`
+	Timeout   time.Duration     `jsonry:"lifecycle.timeout"`
+	Secret    string            `json:"-"`
+	private   string
+}
+
+type State string
+
+type Process struct {
+	Type      string  `jsonry:"type"`
+	Instances *int    `jsonry:"scale.instances"`
+	Memory    *uint64 `jsonry:"scale.memory_in_mb,omitempty"`
+	Command   jsonry.Optional[string]
+}
+
+type Scalars struct {
+	S   string
+	B   bool
+	I   int
+	I8  int8
+	I16 int16
+	I32 int32
+	I64 int64
+	U   uint
+	U8  uint8
+	U16 uint16
+	U32 uint32
+	U64 uint64
+	F32 float32
+	F64 float64
+}
+
+type OmitEmpty struct {
+	S  string                 `json:",omitempty"`
+	B  bool                   `json:",omitempty"`
+	I  int                    `json:",omitempty"`
+	F  float64                `json:",omitempty"`
+	P  *string                `json:",omitempty"`
+	L  []int                  `json:",omitempty"`
+	M  map[string]int         `json:",omitempty"`
+	E  interface{}            `json:",omitempty"`
+	St Scalars                `json:",omitempty"`
+	O  jsonry.Optional[int]   `jsonry:"a.o"`
+	OP *jsonry.Optional[bool] `jsonry:"a.op,omitempty"`
+}
+
+type Lists struct {
+	GUIDs    []string  `jsonry:"relationships.routes.data[].guid"`
+	Ports    []int     `jsonry:"routes[].port"`
+	Names    []string  `jsonry:"names[].name"`
+	Single   string    `jsonry:"single[].value"`
+	Nested   [][]int   `jsonry:"a[].b[].c"`
+	Data     []byte    `jsonry:"data,hex"`
+	Entities []Process `jsonry:"entities"`
+	Raw      json.RawMessage
+}
+
+type Remain struct {
+	Name  string                 `jsonry:"name"`
+	Other map[string]interface{} `jsonry:",remain"`
+}
+
+type Conflict struct {
+	A string `jsonry:"a"`
+	B string `jsonry:"a.b"`
+}
+
+type Default struct {
+	Type      string `jsonry:"lifecycle.type,default=buildpack"`
+	Instances int    `jsonry:"instances" default:"1"`
+}
+
+type Page[T any] struct {
+	Resources []T    `jsonry:"resources"`
+	Next      string `jsonry:"pagination.next.href,omitempty"`
+	Total     int    `jsonry:"pagination.total_results"`
+}
@@ -0,0 +1,422 @@
+// Code generated by "jsonrygen -type=App,Process,Scalars,OmitEmpty,Lists,Remain,Conflict,Default,Page -output=example_jsonry.go"; DO NOT EDIT.
+
+package example
+
+import (
+	"code.cloudfoundry.org/jsonry"
+	"code.cloudfoundry.org/jsonry/gen"
+)
+
+var jsonryApp = gen.NewPlan(
+	gen.Field{Name: "GUID", Tag: `jsonry:"guid"`},
+	gen.Field{Name: "Name", Tag: `jsonry:"name"`},
+	gen.Field{Name: "SpaceGUID", Tag: `jsonry:"relationships.space.data.guid"`},
+	gen.Field{Name: "OrgGUID", Tag: `jsonry:"relationships.space.data.org_guid,omitempty"`},
+	gen.Field{Name: "State", Tag: `jsonry:"state"`},
+	gen.Field{Name: "Processes", Tag: `jsonry:"processes"`},
+	gen.Field{Name: "Labels", Tag: `jsonry:"metadata.labels,omitempty"`},
+	gen.Field{Name: "Timeout", Tag: `jsonry:"lifecycle.timeout"`},
+	gen.Field{Name: "Secret", Tag: `json:"-"`},
+)
+
+// MarshalJSON converts the App into JSON in the same way as jsonry.Marshal()
+func (x App) MarshalJSON() ([]byte, error) {
+	w := jsonryApp.Writer()
+	w.Key("guid")
+	w.String(x.GUID)
+	w.Object("lifecycle")
+	w.Key("timeout")
+	w.Value(7, &x.Timeout)
+	w.End()
+	w.Object("metadata")
+	if len(x.Labels) != 0 {
+		w.Key("labels")
+		w.Value(6, &x.Labels)
+	}
+	w.End()
+	w.Key("name")
+	w.String(x.Name)
+	w.Key("processes")
+	w.Value(5, &x.Processes)
+	w.Object("relationships")
+	w.Object("space")
+	w.Object("data")
+	w.Key("guid")
+	w.String(x.SpaceGUID)
+	if x.OrgGUID != "" {
+		w.Key("org_guid")
+		w.String(x.OrgGUID)
+	}
+	w.End()
+	w.End()
+	w.End()
+	w.Key("state")
+	w.String(string(x.State))
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the App from JSON in the same way as jsonry.Unmarshal()
+func (x *App) UnmarshalJSON(data []byte) error {
+	r, err := jsonryApp.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.String(r, 0, &x.GUID)
+	gen.String(r, 1, &x.Name)
+	gen.String(r, 2, &x.SpaceGUID)
+	gen.String(r, 3, &x.OrgGUID)
+	gen.String(r, 4, &x.State)
+	r.Value(5, &x.Processes)
+	r.Value(6, &x.Labels)
+	r.Value(7, &x.Timeout)
+	gen.String(r, 8, &x.Secret)
+	return r.Err()
+}
+
+var jsonryProcess = gen.NewPlan(
+	gen.Field{Name: "Type", Tag: `jsonry:"type"`},
+	gen.Field{Name: "Instances", Tag: `jsonry:"scale.instances"`},
+	gen.Field{Name: "Memory", Tag: `jsonry:"scale.memory_in_mb,omitempty"`},
+	gen.Field{Name: "Command"},
+)
+
+// MarshalJSON converts the Process into JSON in the same way as jsonry.Marshal()
+func (x Process) MarshalJSON() ([]byte, error) {
+	w := jsonryProcess.Writer()
+	if !x.Command.OmitJSONry() {
+		w.Key("Command")
+		w.Value(3, &x.Command)
+	}
+	w.Object("scale")
+	w.Key("instances")
+	w.Value(1, &x.Instances)
+	if x.Memory != nil {
+		w.Key("memory_in_mb")
+		w.Value(2, &x.Memory)
+	}
+	w.End()
+	w.Key("type")
+	w.String(x.Type)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Process from JSON in the same way as jsonry.Unmarshal()
+func (x *Process) UnmarshalJSON(data []byte) error {
+	r, err := jsonryProcess.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.String(r, 0, &x.Type)
+	r.Value(1, &x.Instances)
+	r.Value(2, &x.Memory)
+	r.Value(3, &x.Command)
+	return r.Err()
+}
+
+var jsonryScalars = gen.NewPlan(
+	gen.Field{Name: "S"},
+	gen.Field{Name: "B"},
+	gen.Field{Name: "I"},
+	gen.Field{Name: "I8"},
+	gen.Field{Name: "I16"},
+	gen.Field{Name: "I32"},
+	gen.Field{Name: "I64"},
+	gen.Field{Name: "U"},
+	gen.Field{Name: "U8"},
+	gen.Field{Name: "U16"},
+	gen.Field{Name: "U32"},
+	gen.Field{Name: "U64"},
+	gen.Field{Name: "F32"},
+	gen.Field{Name: "F64"},
+)
+
+// MarshalJSON converts the Scalars into JSON in the same way as jsonry.Marshal()
+func (x Scalars) MarshalJSON() ([]byte, error) {
+	w := jsonryScalars.Writer()
+	w.Key("B")
+	w.Bool(x.B)
+	w.Key("F32")
+	w.Float32(x.F32)
+	w.Key("F64")
+	w.Float64(x.F64)
+	w.Key("I")
+	w.Int(int64(x.I))
+	w.Key("I16")
+	w.Int(int64(x.I16))
+	w.Key("I32")
+	w.Int(int64(x.I32))
+	w.Key("I64")
+	w.Int(x.I64)
+	w.Key("I8")
+	w.Int(int64(x.I8))
+	w.Key("S")
+	w.String(x.S)
+	w.Key("U")
+	w.Uint(uint64(x.U))
+	w.Key("U16")
+	w.Uint(uint64(x.U16))
+	w.Key("U32")
+	w.Uint(uint64(x.U32))
+	w.Key("U64")
+	w.Uint(x.U64)
+	w.Key("U8")
+	w.Uint(uint64(x.U8))
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Scalars from JSON in the same way as jsonry.Unmarshal()
+func (x *Scalars) UnmarshalJSON(data []byte) error {
+	r, err := jsonryScalars.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.String(r, 0, &x.S)
+	gen.Bool(r, 1, &x.B)
+	gen.Int(r, 2, &x.I)
+	gen.Int(r, 3, &x.I8)
+	gen.Int(r, 4, &x.I16)
+	gen.Int(r, 5, &x.I32)
+	gen.Int(r, 6, &x.I64)
+	gen.Uint(r, 7, &x.U)
+	gen.Uint(r, 8, &x.U8)
+	gen.Uint(r, 9, &x.U16)
+	gen.Uint(r, 10, &x.U32)
+	gen.Uint(r, 11, &x.U64)
+	gen.Float(r, 12, &x.F32)
+	gen.Float(r, 13, &x.F64)
+	return r.Err()
+}
+
+var jsonryOmitEmpty = gen.NewPlan(
+	gen.Field{Name: "S", Tag: `json:",omitempty"`},
+	gen.Field{Name: "B", Tag: `json:",omitempty"`},
+	gen.Field{Name: "I", Tag: `json:",omitempty"`},
+	gen.Field{Name: "F", Tag: `json:",omitempty"`},
+	gen.Field{Name: "P", Tag: `json:",omitempty"`},
+	gen.Field{Name: "L", Tag: `json:",omitempty"`},
+	gen.Field{Name: "M", Tag: `json:",omitempty"`},
+	gen.Field{Name: "E", Tag: `json:",omitempty"`},
+	gen.Field{Name: "St", Tag: `json:",omitempty"`},
+	gen.Field{Name: "O", Tag: `jsonry:"a.o"`},
+	gen.Field{Name: "OP", Tag: `jsonry:"a.op,omitempty"`},
+)
+
+// MarshalJSON converts the OmitEmpty into JSON in the same way as jsonry.Marshal()
+func (x OmitEmpty) MarshalJSON() ([]byte, error) {
+	w := jsonryOmitEmpty.Writer()
+	if x.B {
+		w.Key("B")
+		w.Bool(x.B)
+	}
+	if x.E != nil {
+		w.Key("E")
+		w.Value(7, &x.E)
+	}
+	if x.F != 0 {
+		w.Key("F")
+		w.Float64(x.F)
+	}
+	if x.I != 0 {
+		w.Key("I")
+		w.Int(int64(x.I))
+	}
+	if len(x.L) != 0 {
+		w.Key("L")
+		w.Value(5, &x.L)
+	}
+	if len(x.M) != 0 {
+		w.Key("M")
+		w.Value(6, &x.M)
+	}
+	if x.P != nil {
+		w.Key("P")
+		w.Value(4, &x.P)
+	}
+	if x.S != "" {
+		w.Key("S")
+		w.String(x.S)
+	}
+	w.Key("St")
+	w.Value(8, &x.St)
+	w.Object("a")
+	if !x.O.OmitJSONry() {
+		w.Key("o")
+		w.Value(9, &x.O)
+	}
+	if x.OP != nil && !x.OP.OmitJSONry() {
+		w.Key("op")
+		w.Value(10, &x.OP)
+	}
+	w.End()
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the OmitEmpty from JSON in the same way as jsonry.Unmarshal()
+func (x *OmitEmpty) UnmarshalJSON(data []byte) error {
+	r, err := jsonryOmitEmpty.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.String(r, 0, &x.S)
+	gen.Bool(r, 1, &x.B)
+	gen.Int(r, 2, &x.I)
+	gen.Float(r, 3, &x.F)
+	r.Value(4, &x.P)
+	r.Value(5, &x.L)
+	r.Value(6, &x.M)
+	r.Value(7, &x.E)
+	r.Value(8, &x.St)
+	r.Value(9, &x.O)
+	r.Value(10, &x.OP)
+	return r.Err()
+}
+
+var jsonryLists = gen.NewPlan(
+	gen.Field{Name: "GUIDs", Tag: `jsonry:"relationships.routes.data[].guid"`},
+	gen.Field{Name: "Ports", Tag: `jsonry:"routes[].port"`},
+	gen.Field{Name: "Names", Tag: `jsonry:"names[].name"`},
+	gen.Field{Name: "Single", Tag: `jsonry:"single[].value"`},
+	gen.Field{Name: "Nested", Tag: `jsonry:"a[].b[].c"`},
+	gen.Field{Name: "Data", Tag: `jsonry:"data,hex"`},
+	gen.Field{Name: "Entities", Tag: `jsonry:"entities"`},
+	gen.Field{Name: "Raw"},
+)
+
+// MarshalJSON converts the Lists into JSON in the same way as jsonry.Marshal()
+func (x Lists) MarshalJSON() ([]byte, error) {
+	w := jsonryLists.Writer()
+	w.Key("Raw")
+	w.Value(7, &x.Raw)
+	w.Key("a")
+	w.Spread(4, &x.Nested)
+	w.Key("data")
+	w.Value(5, &x.Data)
+	w.Key("entities")
+	w.Value(6, &x.Entities)
+	w.Key("names")
+	w.Spread(2, &x.Names)
+	w.Object("relationships")
+	w.Object("routes")
+	w.Key("data")
+	w.Spread(0, &x.GUIDs)
+	w.End()
+	w.End()
+	w.Key("routes")
+	w.Spread(1, &x.Ports)
+	w.Key("single")
+	w.Spread(3, &x.Single)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Lists from JSON in the same way as jsonry.Unmarshal()
+func (x *Lists) UnmarshalJSON(data []byte) error {
+	r, err := jsonryLists.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.GUIDs)
+	r.Value(1, &x.Ports)
+	r.Value(2, &x.Names)
+	gen.String(r, 3, &x.Single)
+	r.Value(4, &x.Nested)
+	r.Value(5, &x.Data)
+	r.Value(6, &x.Entities)
+	r.Value(7, &x.Raw)
+	return r.Err()
+}
+
+// MarshalJSON converts the Remain into JSON using jsonry.Marshal(), because it has a ",remain" field
+func (x Remain) MarshalJSON() ([]byte, error) {
+	return jsonry.Marshal(x)
+}
+
+// UnmarshalJSON sets the Remain from JSON using jsonry.Unmarshal(), because it has a ",remain" field
+func (x *Remain) UnmarshalJSON(data []byte) error {
+	return jsonry.Unmarshal(data, x)
+}
+
+var jsonryConflict = gen.NewPlan(
+	gen.Field{Name: "A", Tag: `jsonry:"a"`},
+	gen.Field{Name: "B", Tag: `jsonry:"a.b"`},
+)
+
+// MarshalJSON converts the Conflict into JSON using jsonry.Marshal(), because the path of field "B" conflicts with another field
+func (x Conflict) MarshalJSON() ([]byte, error) {
+	return jsonry.Marshal(x)
+}
+
+// UnmarshalJSON sets the Conflict from JSON in the same way as jsonry.Unmarshal()
+func (x *Conflict) UnmarshalJSON(data []byte) error {
+	r, err := jsonryConflict.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.String(r, 0, &x.A)
+	gen.String(r, 1, &x.B)
+	return r.Err()
+}
+
+var jsonryDefault = gen.NewPlan(
+	gen.Field{Name: "Type", Tag: `jsonry:"lifecycle.type,default=buildpack"`},
+	gen.Field{Name: "Instances", Tag: `jsonry:"instances" default:"1"`},
+)
+
+// MarshalJSON converts the Default into JSON in the same way as jsonry.Marshal()
+func (x Default) MarshalJSON() ([]byte, error) {
+	w := jsonryDefault.Writer()
+	w.Key("instances")
+	w.Int(int64(x.Instances))
+	w.Object("lifecycle")
+	w.Key("type")
+	w.String(x.Type)
+	w.End()
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Default from JSON using jsonry.Unmarshal(), because field "Instances" has a default value
+func (x *Default) UnmarshalJSON(data []byte) error {
+	return jsonry.Unmarshal(data, x)
+}
+
+var jsonryPage = gen.NewPlan(
+	gen.Field{Name: "Resources", Tag: `jsonry:"resources"`},
+	gen.Field{Name: "Next", Tag: `jsonry:"pagination.next.href,omitempty"`},
+	gen.Field{Name: "Total", Tag: `jsonry:"pagination.total_results"`},
+)
+
+// MarshalJSON converts the Page into JSON in the same way as jsonry.Marshal()
+func (x Page[T]) MarshalJSON() ([]byte, error) {
+	w := jsonryPage.Writer()
+	w.Object("pagination")
+	w.Object("next")
+	if x.Next != "" {
+		w.Key("href")
+		w.String(x.Next)
+	}
+	w.End()
+	w.Key("total_results")
+	w.Int(int64(x.Total))
+	w.End()
+	w.Key("resources")
+	w.Value(0, &x.Resources)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Page from JSON in the same way as jsonry.Unmarshal()
+func (x *Page[T]) UnmarshalJSON(data []byte) error {
+	r, err := jsonryPage.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.Resources)
+	gen.String(r, 1, &x.Next)
+	gen.Int(r, 2, &x.Total)
+	return r.Err()
+}
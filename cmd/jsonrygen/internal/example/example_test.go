@@ -0,0 +1,264 @@
+package example_test
+
+import (
+	"encoding/json"
+	"math"
+	"time"
+
+	"code.cloudfoundry.org/jsonry"
+	"code.cloudfoundry.org/jsonry/cmd/jsonrygen/internal/example"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("generated methods", func() {
+	ptr := func(i int) *int { return &i }
+
+	DescribeTable("MarshalJSON produces the same JSON as jsonry.Marshal()",
+		func(input json.Marshaler, expected string) {
+			generated, err := input.MarshalJSON()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(generated).To(MatchJSON(expected))
+
+			reflected, err := jsonry.Marshal(input)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(generated)).To(Equal(string(reflected)))
+		},
+		Entry("nested paths",
+			example.App{
+				GUID:      "app-guid",
+				Name:      "<my & app>",
+				SpaceGUID: "space-guid",
+				OrgGUID:   "org-guid",
+				State:     "STARTED",
+				Processes: []example.Process{{Type: "web", Instances: ptr(2), Command: jsonry.Some("run")}},
+				Labels:    map[string]string{"b": "2", "a": "1"},
+				Timeout:   time.Minute,
+				Secret:    "hidden",
+			},
+			`{
+				"guid": "app-guid",
+				"lifecycle": {"timeout": 60000000000},
+				"metadata": {"labels": {"a": "1", "b": "2"}},
+				"name": "<my & app>",
+				"processes": [{"Command": "run", "scale": {"instances": 2}, "type": "web"}],
+				"relationships": {"space": {"data": {"guid": "space-guid", "org_guid": "org-guid"}}},
+				"state": "STARTED"
+			}`,
+		),
+		Entry("omitted fields and empty objects",
+			example.App{Name: "quoted \"name\" with \\ and  "},
+			`{
+				"guid": "",
+				"lifecycle": {"timeout": 0},
+				"name": "quoted \"name\" with \\ and  ",
+				"processes": null,
+				"relationships": {"space": {"data": {"guid": ""}}},
+				"state": ""
+			}`,
+		),
+		Entry("scalars",
+			example.Scalars{
+				S: "s", B: true,
+				I: -1, I8: math.MinInt8, I16: math.MinInt16, I32: math.MinInt32, I64: math.MinInt64,
+				U: 1, U8: math.MaxUint8, U16: math.MaxUint16, U32: math.MaxUint32, U64: math.MaxUint64,
+				F32: 3.14, F64: 1e21,
+			},
+			`{
+				"B": true, "F32": 3.14, "F64": 1e+21,
+				"I": -1, "I16": -32768, "I32": -2147483648, "I64": -9223372036854775808, "I8": -128,
+				"S": "s",
+				"U": 1, "U16": 65535, "U32": 4294967295, "U64": 18446744073709551615, "U8": 255
+			}`,
+		),
+		Entry("small floats",
+			example.Scalars{F32: 1e-7, F64: 0.000001},
+			`{
+				"B": false, "F32": 1e-7, "F64": 0.000001,
+				"I": 0, "I16": 0, "I32": 0, "I64": 0, "I8": 0,
+				"S": "",
+				"U": 0, "U16": 0, "U32": 0, "U64": 0, "U8": 0
+			}`,
+		),
+		Entry("omitempty with empty values",
+			example.OmitEmpty{},
+			`{
+				"St": {
+					"B": false, "F32": 0, "F64": 0,
+					"I": 0, "I16": 0, "I32": 0, "I64": 0, "I8": 0,
+					"S": "",
+					"U": 0, "U16": 0, "U32": 0, "U64": 0, "U8": 0
+				}
+			}`,
+		),
+		Entry("omitempty with values",
+			example.OmitEmpty{
+				S: "s", B: true, I: 1, F: 1.5, P: new(string), L: []int{1}, M: map[string]int{"a": 1},
+				E: example.Process{Type: "worker"}, O: jsonry.Null[int](), OP: &jsonry.Optional[bool]{},
+			},
+			`{
+				"B": true, "E": {"scale": {"instances": null}, "type": "worker"}, "F": 1.5, "I": 1,
+				"L": [1], "M": {"a": 1}, "P": "", "S": "s",
+				"St": {
+					"B": false, "F32": 0, "F64": 0,
+					"I": 0, "I16": 0, "I32": 0, "I64": 0, "I8": 0,
+					"S": "",
+					"U": 0, "U16": 0, "U32": 0, "U64": 0, "U8": 0
+				},
+				"a": {"o": null}
+			}`,
+		),
+		Entry("list hints",
+			example.Lists{
+				GUIDs:    []string{"a", "b"},
+				Ports:    []int{8080},
+				Names:    []string{"x", "y"},
+				Single:   "one",
+				Nested:   [][]int{{1, 2}, {3}},
+				Data:     []byte{0xca, 0xfe},
+				Entities: []example.Process{{Type: "web"}},
+				Raw:      json.RawMessage(`{"z": 1, "a": [ 2 ]}`),
+			},
+			`{
+				"Raw": {"z": 1, "a": [2]},
+				"a": [{"b": [{"c": 1}, {"c": 2}]}, {"b": [{"c": 3}]}],
+				"data": "cafe",
+				"entities": [{"scale": {"instances": null}, "type": "web"}],
+				"names": [{"name": "x"}, {"name": "y"}],
+				"relationships": {"routes": {"data": [{"guid": "a"}, {"guid": "b"}]}},
+				"routes": [{"port": 8080}],
+				"single": [{"value": "one"}]
+			}`,
+		),
+		Entry("empty lists",
+			example.Lists{},
+			`{
+				"Raw": null,
+				"a": null,
+				"data": null,
+				"entities": null,
+				"names": null,
+				"relationships": {"routes": {"data": null}},
+				"routes": null,
+				"single": [{"value": ""}]
+			}`,
+		),
+		Entry("a struct with a remainder",
+			example.Remain{Name: "n", Other: map[string]interface{}{"name": "ignored", "extra": true}},
+			`{"extra": true, "name": "n"}`,
+		),
+		Entry("a struct with conflicting paths",
+			example.Conflict{A: "a", B: "b"},
+			`{"a": {"b": "b"}}`,
+		),
+		Entry("a struct with default values",
+			example.Default{Type: "buildpack"},
+			`{"instances": 0, "lifecycle": {"type": "buildpack"}}`,
+		),
+		Entry("a generic struct",
+			example.Page[example.Process]{Resources: []example.Process{{Type: "web"}}, Total: 1},
+			`{"pagination": {"total_results": 1}, "resources": [{"scale": {"instances": null}, "type": "web"}]}`,
+		),
+	)
+
+	DescribeTable("MarshalJSON returns the same errors as jsonry.Marshal()",
+		func(input json.Marshaler) {
+			_, generated := input.MarshalJSON()
+			_, reflected := jsonry.Marshal(input)
+			Expect(reflected).To(HaveOccurred())
+			Expect(generated).To(MatchError(reflected.Error()))
+		},
+		Entry("NaN", example.Scalars{F64: math.NaN()}),
+		Entry("infinity", example.Scalars{F32: float32(math.Inf(-1))}),
+		Entry("unsupported type", example.OmitEmpty{E: func() {}}),
+		Entry("invalid json.RawMessage", example.Lists{Raw: json.RawMessage(`{`)}),
+	)
+
+	DescribeTable("UnmarshalJSON produces the same result as jsonry.Unmarshal()",
+		func(data string, generated, reflected json.Unmarshaler) {
+			Expect(json.Unmarshal([]byte(data), generated)).To(Succeed())
+			Expect(jsonry.Unmarshal([]byte(data), reflected)).To(Succeed())
+			Expect(generated).To(Equal(reflected))
+		},
+		Entry("nested paths",
+			`{
+				"guid": "app-guid",
+				"name": "my \"app\"",
+				"relationships": {"space": {"data": {"org_guid": "org-guid", "guid": "space-guid"}}, "unknown": {}},
+				"state": "STOPPED",
+				"processes": [{"type": "web", "scale": {"instances": 3, "memory_in_mb": 256}, "Command": null}],
+				"metadata": {"labels": {"a": "1"}},
+				"lifecycle": {"timeout": 1000},
+				"Secret": "not so secret"
+			}`,
+			new(example.App), new(example.App),
+		),
+		Entry("null values",
+			`{"guid": null, "processes": null, "relationships": null}`,
+			&example.App{GUID: "kept"}, &example.App{GUID: "kept"},
+		),
+		Entry("null",
+			`null`,
+			new(example.App), new(example.App),
+		),
+		Entry("scalars",
+			`{
+				"S": "été", "B": true,
+				"I": -1, "I8": -128, "I16": -32768, "I32": -2147483648, "I64": -9223372036854775808,
+				"U": 1, "U8": 255, "U16": 65535, "U32": 4294967295, "U64": 18446744073709551615,
+				"F32": 3.4e38, "F64": -1.5e-300
+			}`,
+			new(example.Scalars), new(example.Scalars),
+		),
+		Entry("list hints",
+			`{
+				"relationships": {"routes": {"data": [{"guid": "a"}, {"other": 1}, {"guid": "c"}]}},
+				"routes": [{"port": 1}, [{"port": 2}, {"port": 3}]],
+				"names": [{"name": "x"}, {"name": "y"}],
+				"a": [{"b": [{"c": 1}, {"c": 2}]}, {"b": [{"c": 3}]}],
+				"data": "cafe",
+				"entities": [{"type": "web"}],
+				"Raw": {"z": 1, "a": [ 2 ]}
+			}`,
+			new(example.Lists), new(example.Lists),
+		),
+		Entry("a struct with a remainder",
+			`{"name": "n", "extra": [true]}`,
+			new(example.Remain), new(example.Remain),
+		),
+		Entry("a struct with conflicting paths",
+			`{"a": "a"}`,
+			new(example.Conflict), new(example.Conflict),
+		),
+		Entry("a struct with default values",
+			`{"lifecycle": {}}`,
+			new(example.Default), new(example.Default),
+		),
+		Entry("a generic struct",
+			`{"pagination": {"next": {"href": "/next"}, "total_results": 2}, "resources": [{"type": "web"}, {"type": "worker"}]}`,
+			new(example.Page[example.Process]), new(example.Page[example.Process]),
+		),
+	)
+
+	DescribeTable("UnmarshalJSON returns the same errors as jsonry.Unmarshal()",
+		func(data string, generated, reflected json.Unmarshaler) {
+			err := generated.UnmarshalJSON([]byte(data))
+			expected := jsonry.Unmarshal([]byte(data), reflected)
+			Expect(expected).To(HaveOccurred())
+			Expect(err).To(MatchError(expected.Error()))
+			Expect(generated).To(Equal(reflected))
+		},
+		Entry("invalid JSON", `{"S":`, new(example.Scalars), new(example.Scalars)),
+		Entry("not an object", `[1]`, new(example.Scalars), new(example.Scalars)),
+		Entry("wrong type for a string", `{"S": 1}`, new(example.Scalars), new(example.Scalars)),
+		Entry("wrong type for a bool", `{"B": "true"}`, new(example.Scalars), new(example.Scalars)),
+		Entry("wrong type for an int", `{"I": 1.5}`, new(example.Scalars), new(example.Scalars)),
+		Entry("int out of range", `{"I8": 128}`, new(example.Scalars), new(example.Scalars)),
+		Entry("negative uint", `{"U": -1}`, new(example.Scalars), new(example.Scalars)),
+		Entry("uint out of range", `{"U16": 65536}`, new(example.Scalars), new(example.Scalars)),
+		Entry("float out of range", `{"F32": 3.5e38}`, new(example.Scalars), new(example.Scalars)),
+		Entry("the first error", `{"S": "ok", "I": "1", "U": "1"}`, new(example.Scalars), new(example.Scalars)),
+		Entry("a list hint into a string", `{"single": [{"value": "one"}]}`, new(example.Lists), new(example.Lists)),
+		Entry("a nested struct", `{"processes": [{"type": 1}]}`, new(example.App), new(example.App)),
+	)
+})
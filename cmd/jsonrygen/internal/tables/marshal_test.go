@@ -0,0 +1,168 @@
+package tables_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"code.cloudfoundry.org/jsonry"
+	"code.cloudfoundry.org/jsonry/cmd/jsonrygen/internal/tables"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MarshalJSON", func() {
+	i := 42
+	s := []interface{}{"hello", true, 42}
+	a := [3]interface{}{"hello", true, 42}
+	ss := []string{"hello", "true", "42"}
+	empty := make([]string, 0)
+	var null []string
+	mi := map[string]interface{}{"foo": "hello", "bar": true, "baz": 42}
+	ms := map[string]string{"foo": "hello", "bar": "true", "baz": "42"}
+	me := make(map[string]string)
+	b := []byte("hello\xff")
+	r := json.RawMessage(`{"z": 1, "a": [1.50e1, "\u00e9"]}`)
+
+	DescribeTable("produces the same JSON as the tests for jsonry.Marshal()",
+		func(input json.Marshaler, expected string) {
+			generated, err := input.MarshalJSON()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(generated).To(MatchJSON(expected))
+
+			reflected, err := jsonry.Marshal(input)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(generated)).To(Equal(string(reflected)))
+		},
+		Entry("a basic string field", tables.Basic{Foo: "works"}, `{"Foo": "works"}`),
+		Entry("the path defaulting to the field name", tables.FieldName{GUID: "123"}, `{"GUID":"123"}`),
+		Entry("a JSON tag", tables.JSONTag{GUID: "123"}, `{"guid":"123"}`),
+		Entry("a JSONry tag", tables.ListHintPath{GUID: "123"}, `{"relationships":{"spaces":[{"guid":"123"}]}}`),
+		Entry("a string", tables.String{S: "hello"}, `{"S":"hello"}`),
+		Entry("a boolean", tables.Bool{T: true, F: false}, `{"T":true, "F":false}`),
+		Entry("an int", tables.Int{I: 42}, `{"I":42}`),
+		Entry("an int8", tables.Int8{I: -42}, `{"I":-42}`),
+		Entry("an int16", tables.Int16{I: 42}, `{"I":42}`),
+		Entry("an int32", tables.Int32{I: -42}, `{"I":-42}`),
+		Entry("an int64", tables.Int64{I: 42}, `{"I":42}`),
+		Entry("a uint", tables.Uint{I: 42}, `{"I":42}`),
+		Entry("a uint8", tables.Uint8{I: 42}, `{"I":42}`),
+		Entry("a uint16", tables.Uint16{I: 42}, `{"I":42}`),
+		Entry("a uint32", tables.Uint32{I: 42}, `{"I":42}`),
+		Entry("a uint64", tables.Uint64{I: 42}, `{"I":42}`),
+		Entry("a float32", tables.Float32{E: 4.2e15, F: 4.2}, `{"E":4.2e15,"F":4.2}`),
+		Entry("a float64", tables.Float64{E: 4.2e-15, F: 4.2}, `{"E":4.2e-15,"F":4.2}`),
+		Entry("a pointer", tables.Pointer{P: &i}, `{"P":42}`),
+		Entry("a nil interface", tables.NilInterface{N: nil}, `{"N":null}`),
+		Entry("a nil pointer", tables.NilPointer{N: nil}, `{"N":null}`),
+		Entry("a struct with a private field", tables.Private{P: "foo"}, `{"P":"foo"}`),
+		Entry("an array", tables.Array{S: a}, `{"S":["hello",true,42]}`),
+		Entry("a pointer to an array", tables.ArrayPointer{S: &a}, `{"S":["hello",true,42]}`),
+		Entry("a slice with interface{} values", tables.Slice{S: s}, `{"S":["hello",true,42]}`),
+		Entry("a pointer to a slice with interface{} values", tables.SlicePointer{S: &s}, `{"S":["hello",true,42]}`),
+		Entry("a slice with string values", tables.Strings{S: ss}, `{"S":["hello","true","42"]}`),
+		Entry("a pointer to a slice with string values", tables.StringsPointer{S: &ss}, `{"S":["hello","true","42"]}`),
+		Entry("an empty slice", tables.Strings{S: empty}, `{"S":[]}`),
+		Entry("a pointer to an empty slice", tables.StringsPointer{S: &empty}, `{"S":[]}`),
+		Entry("a nil slice", tables.Strings{S: null}, `{"S":null}`),
+		Entry("a pointer to a nil slice", tables.StringsPointer{S: &null}, `{"S":null}`),
+		Entry("a map with interface{} values", tables.Map{M: mi}, `{"M":{"foo":"hello","bar":true,"baz":42}}`),
+		Entry("a pointer to a map with interface{} values", tables.MapPointer{M: &mi}, `{"M":{"foo":"hello","bar":true,"baz":42}}`),
+		Entry("a map with string values", tables.MapString{M: ms}, `{"M":{"foo":"hello","bar":"true","baz":"42"}}`),
+		Entry("a pointer to a map with string values", tables.MapStringPointer{M: &ms}, `{"M":{"foo":"hello","bar":"true","baz":"42"}}`),
+		Entry("an empty map", tables.MapString{M: me}, `{"M":{}}`),
+		Entry("a pointer to an empty map", tables.MapStringPointer{M: &me}, `{"M":{}}`),
+		Entry("a nil map", tables.MapString{}, `{"M":null}`),
+		Entry("a nil pointer to a map", tables.MapStringPointer{}, `{"M":null}`),
+		Entry("a map with keys that are string type definitions", tables.MapStringy{M: map[tables.Stringy]string{"foo": "hello"}}, `{"M":{"foo": "hello"}}`),
+		Entry("a []byte as base64", tables.Bytes{B: b}, `{"B":"aGVsbG//"}`),
+		Entry("a pointer to a []byte as base64", tables.BytesPointer{B: &b}, `{"B":"aGVsbG//"}`),
+		Entry("an empty []byte", tables.Bytes{B: []byte{}}, `{"B":""}`),
+		Entry("a nil []byte", tables.Bytes{}, `{"B":null}`),
+		Entry("a []byte as URL-safe base64", tables.Base64URL{B: b}, `{"b":"aGVsbG__","c":null}`),
+		Entry("a []byte as hex", tables.Hex{C: []byte{0xde, 0xad, 0xbe, 0xef}}, `{"c":{"d":"deadbeef"}}`),
+		Entry("slices and maps of []byte as hex",
+			tables.HexLists{L: [][]byte{{1}, {2, 3}}, M: map[string][]byte{"a": {0xff}}},
+			`{"a":{"b":null},"L":["01","0203"],"M":{"a":"ff"}}`,
+		),
+		Entry("a named byte slice type", tables.NamedBytes{B: tables.ByteSlice("hi")}, `{"B":"aGk="}`),
+		Entry("a byte array as an array", tables.ByteArray{B: [2]byte{1, 2}}, `{"B":[1,2]}`),
+		Entry("a json.Marshaler", tables.JSONMarshaler{I: tables.ImplementsJSONMarshaler{Output: []byte(`"hello"`)}}, `{"I":"hello"}`),
+		Entry("a pointer to a json.Marshaler", tables.JSONMarshalerPointer{I: &tables.ImplementsJSONMarshaler{Output: []byte(`"hello"`)}}, `{"I":"hello"}`),
+		Entry("a nil pointer to a json.Marshaler", tables.JSONMarshalerPointer{}, `{"I":null}`),
+		Entry("a json.RawMessage as it is", tables.RawMessages{R: r, P: &r}, `{"N":null,"Q":null,"a":{"b":{"z":1,"a":[1.50e1,"\u00e9"]},"c":{"z":1,"a":[1.50e1,"\u00e9"]},"d":null}}`),
+		Entry("named types and type aliases", tables.Named{A: "foo", N: tables.NamedString("bar")}, `{"A":"foo","N":"bar"}`),
+		Entry("a struct within a struct", tables.InStruct{T: tables.Inner{S: "foo"}}, `{"T":{"S":"foo"}}`),
+		Entry("a struct within a slice", tables.InSlice{T: []tables.Inner{{S: "foo"}, {S: "bar"}}}, `{"T":[{"S":"foo"},{"S":"bar"}]}`),
+		Entry("a struct within a map", tables.InMap{T: map[string]tables.Inner{"A": {S: "foo"}, "B": {S: "bar"}}}, `{"T":{"A":{"S":"foo"},"B":{"S":"bar"}}}`),
+		Entry("omitempty from JSON and JSONry tags with and without names", tables.OmitEmptyTags{}, `{}`),
+		Entry("omitting false", tables.OmitFalse{}, `{}`),
+		Entry("omitting 0", tables.OmitZero{}, `{}`),
+		Entry("omitting nil pointers", tables.OmitNilPointers{}, `{}`),
+		Entry("omitting nil interface values", tables.OmitNilInterfaces{}, `{}`),
+		Entry("omitting empty arrays", tables.OmitEmptyArray{}, `{}`),
+		Entry("omitting empty slices", tables.OmitEmptySlices{B: []int{}, C: make([]int, 0, 1)}, `{}`),
+		Entry("omitting empty maps", tables.OmitEmptyMaps{D: make(map[int]int)}, `{}`),
+		Entry("omitting empty strings", tables.OmitEmptyStrings{}, `{}`),
+		Entry("not omitting empty structs", tables.OmitEmptyStruct{}, `{"B":{}}`),
+		Entry("omitting fields tagged with `-`", tables.OmitAlways{A: "foo"}, `{}`),
+		Entry("a literal field name `-`", tables.Dash{A: "foo"}, `{"-":"foo"}`),
+		Entry("an Omissible that chooses to be marshaled", tables.Omissible{A: ""}, `{"A": ""}`),
+		Entry("an Omissible that chooses not to be marshaled", tables.Omissible{A: "omit"}, `{}`),
+		Entry("an Omissible overriding omitempty", tables.OmissibleOverride{}, `{"A": ""}`),
+		Entry("an Omissible called for a nil pointer", tables.OmitsNilPointers{B: &tables.OmitsNil{}}, `{"B": {}}`),
+		Entry("an Omissible not called for a nil pointer when it has a value receiver", tables.OmissiblePointers{}, `{"A": null}`),
+		Entry("a remainder merged into the object",
+			tables.Remain{
+				Name:  "foo",
+				GUID:  "bar",
+				Other: map[string]interface{}{"name": "ignored", "state": "STARTED", "links": map[string]interface{}{"self": "baz"}},
+			},
+			`{"name":"foo","metadata":{"guid":"bar"},"state":"STARTED","links":{"self":"baz"}}`,
+		),
+		Entry("a remainder merged into the objects made by the paths of fields",
+			tables.RemainMerge{
+				GUID:  "bar",
+				Child: map[string]int{"a": 1},
+				Other: map[string]interface{}{
+					"metadata": map[string]interface{}{"guid": "ignored", "labels": json.RawMessage(`{"x":"y"}`)},
+					"child":    map[string]interface{}{"b": 2},
+				},
+			},
+			`{"metadata":{"guid":"bar","labels":{"x":"y"}},"child":{"a":1}}`,
+		),
+		Entry("a nil remainder", tables.Remain{Name: "foo"}, `{"name":"foo","metadata":{"guid":""}}`),
+	)
+
+	DescribeTable("returns the same errors as the tests for jsonry.Marshal()",
+		func(input json.Marshaler, message string) {
+			_, generated := input.MarshalJSON()
+			Expect(generated).To(MatchError(message))
+
+			_, reflected := jsonry.Marshal(input)
+			Expect(reflected).To(MatchError(message))
+		},
+		Entry("a complex64", tables.Complex64{C: complex(1, 2)}, `unsupported type "complex64" at field "C" (type "complex64")`),
+		Entry("a complex128", tables.Complex128{C: complex(1, 2)}, `unsupported type "complex128" at field "C" (type "complex128")`),
+		Entry("a channel", tables.Chan{C: make(chan bool)}, `unsupported type "chan bool" at field "C" (type "chan bool")`),
+		Entry("a function", tables.Func{F: func() {}}, `unsupported type "func()" at field "F" (type "func()")`),
+		Entry("a map with invalid keys",
+			tables.MapIntKeys{M: map[int]interface{}{4: 3}},
+			`maps must only have string keys for "map[int]interface {}" at field "M" (type "map[int]interface {}")`,
+		),
+		Entry("an error from a json.Marshaler",
+			tables.JSONMarshaler{I: tables.ImplementsJSONMarshaler{Err: errors.New("ouch")}},
+			`error from MarshalJSON() call at field "I" (type "tables.ImplementsJSONMarshaler"): ouch`,
+		),
+		Entry("invalid output from a json.Marshaler",
+			tables.JSONMarshaler{},
+			`error parsing MarshalJSON() output "" at field "I" (type "tables.ImplementsJSONMarshaler"): unexpected end of JSON input`,
+		),
+		Entry("an invalid json.RawMessage",
+			tables.RawMessages{R: json.RawMessage(`{"a"`)},
+			fmt.Sprintf(`error parsing json.RawMessage "{"a"" at field "R" (type "%s"): unexpected end of JSON input`, reflect.TypeOf(r)),
+		),
+		Entry("a remainder that is not a map", tables.RemainNotMap{}, `unsupported type "string" at field "Other" (type "string")`),
+	)
+})
@@ -0,0 +1,409 @@
+// Package tables has named versions of the struct types in the tests for jsonry.Marshal() and
+// jsonry.Unmarshal(), with generated methods, so that the same tests are run against the generated code
+package tables
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Types that are only used in the fields of other types, such as Inner, do not have generated methods,
+// because methods would make them a json.Unmarshaler, which jsonry.Unmarshal() treats differently.
+//
+//go:generate go run code.cloudfoundry.org/jsonry/cmd/jsonrygen -type=Basic,FieldName,JSONTag,ListHintPath,NestedPath,String,Bool,Int,Int8,Int16,Int32,Int64,Uint,Uint8,Uint16,Uint32,Uint64,Float32,Float64,SizedInts,SizedUints,Floats,NestedSmall,PointerInt8,IntUint,Complex64,Complex128,Chan,Func,Pointer,NilInterface,NilPointer,Private,Interfaces,BasicPointers,Array,ArrayPointer,StringArray,Slice,SlicePointer,Strings,StringsPointer,Ints,IntsPointer,Map,MapPointer,MapString,MapStringPointer,MapInt,MapIntPointer,MapIntKeys,MapStringy,Bytes,BytesPointer,Base64URL,Hex,HexLists,NamedBytes,ByteArray,JSONMarshaler,JSONMarshalerPointer,JSONUnmarshaler,RecordsJSONs,RawMessages,RawMessageLists,Named,Nulls,NullStruct,NullPointer,NullInterface,InStruct,InPointer,InSlice,InMap,OmitEmptyTags,OmitFalse,OmitZero,OmitNilPointers,OmitNilInterfaces,OmitEmptyArray,OmitEmptySlices,OmitEmptyMaps,OmitEmptyStrings,OmitEmptyStruct,OmitAlways,Dash,Omissible,OmissibleOverride,OmitsNilPointers,OmissiblePointers,SharedPrefix,PrefixField,Repeated,Escapes,Remain,RemainMerge,RemainNotMap,RemainRaw,RemainNested -output=tables_jsonry.go
+
+type Basic struct{ Foo string }
+
+type FieldName struct{ GUID string }
+
+type JSONTag struct {
+	GUID string `json:"guid"`
+}
+
+type ListHintPath struct {
+	GUID string `jsonry:"relationships.spaces[].guid"`
+}
+
+type NestedPath struct {
+	GUID string `jsonry:"relationships.spaces.guid"`
+}
+
+type String struct{ S string }
+
+type Bool struct{ T, F bool }
+
+type Int struct{ I int }
+
+type Int8 struct{ I int8 }
+
+type Int16 struct{ I int16 }
+
+type Int32 struct{ I int32 }
+
+type Int64 struct{ I int64 }
+
+type Uint struct{ I uint }
+
+type Uint8 struct{ I uint8 }
+
+type Uint16 struct{ I uint16 }
+
+type Uint32 struct{ I uint32 }
+
+type Uint64 struct{ I uint64 }
+
+type Float32 struct{ E, F float32 }
+
+type Float64 struct{ E, F float64 }
+
+type SizedInts struct {
+	I8  int8
+	I16 int16
+	I32 int32
+	I64 int64
+}
+
+type SizedUints struct {
+	U8  uint8
+	U64 uint64
+}
+
+type Floats struct {
+	F32 float32
+	F64 float64
+}
+
+type Small struct{ I int8 }
+
+type NestedSmall struct {
+	L []Small `jsonry:"a.b"`
+}
+
+type PointerInt8 struct{ P *int8 }
+
+type IntUint struct {
+	I int
+	U uint
+}
+
+type Complex64 struct{ C complex64 }
+
+type Complex128 struct{ C complex128 }
+
+type Chan struct{ C chan bool }
+
+type Func struct{ F func() }
+
+type Pointer struct{ P *int }
+
+type NilInterface struct{ N interface{} }
+
+type NilPointer struct{ N *string }
+
+type Private struct{ P, p string }
+
+type Interfaces struct{ N, B, S, I, U, F, L, M interface{} }
+
+type BasicPointers struct {
+	S, T *string
+	I, J *int
+}
+
+type Array struct{ S [3]interface{} }
+
+type ArrayPointer struct{ S *[3]interface{} }
+
+type StringArray struct{ S [3]string }
+
+type Slice struct{ S []interface{} }
+
+type SlicePointer struct{ S *[]interface{} }
+
+type Strings struct{ S []string }
+
+type StringsPointer struct{ S *[]string }
+
+type Ints struct{ N []int }
+
+type IntsPointer struct{ N *[]int }
+
+type Map struct{ M map[string]interface{} }
+
+type MapPointer struct{ M *map[string]interface{} }
+
+type MapString struct{ M map[string]string }
+
+type MapStringPointer struct{ M *map[string]string }
+
+type MapInt struct{ N map[string]int }
+
+type MapIntPointer struct{ N *map[string]int }
+
+type MapIntKeys struct{ M map[int]interface{} }
+
+type Stringy string
+
+type MapStringy struct{ M map[Stringy]string }
+
+type Bytes struct{ B []byte }
+
+type BytesPointer struct{ B *[]byte }
+
+type Base64URL struct {
+	B []byte `jsonry:"b,base64url"`
+	C []byte `jsonry:"c,base64url"`
+}
+
+type Hex struct {
+	B []byte `json:"b,hex,omitempty"`
+	C []byte `jsonry:"c.d,omitempty,hex"`
+}
+
+type HexLists struct {
+	B []byte            `jsonry:"a.b,hex"`
+	L [][]byte          `jsonry:",hex"`
+	M map[string][]byte `jsonry:",hex"`
+}
+
+type ByteSlice []byte
+
+type NamedBytes struct{ B ByteSlice }
+
+type ByteArray struct{ B [2]byte }
+
+type JSONMarshaler struct{ I ImplementsJSONMarshaler }
+
+type JSONMarshalerPointer struct{ I *ImplementsJSONMarshaler }
+
+type JSONUnmarshaler struct{ S ImplementsJSONUnmarshaler }
+
+type RecordsJSONs struct {
+	R RecordsJSON   `jsonry:"a.b"`
+	L []RecordsJSON `jsonry:"a.c"`
+}
+
+type RawMessages struct {
+	R json.RawMessage  `jsonry:"a.b"`
+	P *json.RawMessage `jsonry:"a.c"`
+	S json.RawMessage  `jsonry:"a.d"`
+	N json.RawMessage
+	Q *json.RawMessage
+}
+
+type RawMessageLists struct {
+	L []json.RawMessage `jsonry:"items"`
+	M json.RawMessage   `jsonry:"items.data"`
+}
+
+type Alias = string
+
+type NamedString string
+
+type Named struct {
+	A Alias
+	N NamedString
+}
+
+type Nulls struct {
+	S string
+	T int
+	U uint
+	V float64
+	W bool
+}
+
+type NullStruct struct{ A Inner }
+
+type NullPointer struct{ S *string }
+
+type NullInterface struct{ S interface{} }
+
+type Inner struct{ S string }
+
+type InStruct struct{ T Inner }
+
+type InPointer struct{ T *Inner }
+
+type InSlice struct{ T []Inner }
+
+type InMap struct{ T map[string]Inner }
+
+type OmitEmptyTags struct {
+	A string `json:",omitempty"`
+	B string `json:"bee,omitempty"`
+	C string `jsonry:",omitempty"`
+	D string `jsonry:"dee,omitempty"`
+}
+
+type OmitFalse struct {
+	A bool `jsonry:",omitempty"`
+}
+
+type OmitZero struct {
+	A int     `jsonry:",omitempty"`
+	B uint    `jsonry:",omitempty"`
+	C float64 `jsonry:",omitempty"`
+}
+
+type OmitNilPointers struct {
+	A *string      `jsonry:",omitempty"`
+	B *Inner       `jsonry:",omitempty"`
+	C *[]string    `jsonry:",omitempty"`
+	D *map[int]int `jsonry:",omitempty"`
+	E *interface{} `jsonry:",omitempty"`
+}
+
+type OmitNilInterfaces struct {
+	A interface{}    `jsonry:",omitempty"`
+	B json.Marshaler `jsonry:",omitempty"`
+}
+
+type OmitEmptyArray struct {
+	A [0]int `jsonry:",omitempty"`
+}
+
+type OmitEmptySlices struct {
+	A []int `jsonry:",omitempty"`
+	B []int `jsonry:",omitempty"`
+	C []int `jsonry:",omitempty"`
+}
+
+type OmitEmptyMaps struct {
+	A map[interface{}]interface{} `jsonry:",omitempty"`
+	D map[int]int                 `jsonry:",omitempty"`
+}
+
+type OmitEmptyStrings struct {
+	A string `jsonry:",omitempty"`
+	B string `jsonry:",omitempty"`
+}
+
+type InnerOmitEmpty struct {
+	A string `jsonry:",omitempty"`
+}
+
+type OmitEmptyStruct struct {
+	B InnerOmitEmpty `jsonry:",omitempty"`
+}
+
+type OmitAlways struct {
+	A string `jsonry:"-"`
+}
+
+type Dash struct {
+	A string `jsonry:"-,"`
+}
+
+type Omissible struct{ A ImplementsOmissible }
+
+type OmissibleOverride struct {
+	A ImplementsOmissible `jsonry:",omitempty"`
+	B string              `jsonry:",omitempty"`
+}
+
+type OmitsNilPointers struct {
+	A *OmitsNil
+	B *OmitsNil
+}
+
+type OmissiblePointers struct {
+	A *ImplementsOmissible
+	B *ImplementsOmissible `jsonry:",omitempty"`
+}
+
+type SharedPrefix struct {
+	A string   `jsonry:"a.b.c"`
+	B string   `jsonry:"a.b.d"`
+	C string   `jsonry:"a.e"`
+	D []string `jsonry:"a.f.g"`
+	E string   `jsonry:"h"`
+	F []int    `jsonry:"a.f.i"`
+}
+
+type PrefixField struct {
+	A map[string]interface{} `jsonry:"a"`
+	B string                 `jsonry:"a.b"`
+}
+
+type Repeated struct {
+	A string `jsonry:"a.b"`
+	C string `jsonry:"c"`
+}
+
+type Escapes struct {
+	A string `json:"é"`
+}
+
+type Remain struct {
+	Name  string                 `jsonry:"name"`
+	GUID  string                 `jsonry:"metadata.guid"`
+	Other map[string]interface{} `jsonry:",remain"`
+}
+
+type RemainMerge struct {
+	GUID  string                 `jsonry:"metadata.guid"`
+	Child map[string]int         `jsonry:"child"`
+	Other map[string]interface{} `jsonry:",remain"`
+}
+
+type RemainNotMap struct {
+	Other string `jsonry:",remain"`
+}
+
+type RemainRaw struct {
+	Type   string                     `jsonry:"lifecycle.type"`
+	Stacks []string                   `jsonry:"lifecycle.data.stacks"`
+	Names  []string                   `jsonry:"apps[].name"`
+	Other  map[string]json.RawMessage `jsonry:",remain"`
+}
+
+type InnerRemain struct {
+	A     int
+	Other map[string]int `jsonry:",remain"`
+}
+
+type RemainNested struct {
+	I InnerRemain `jsonry:"i"`
+}
+
+type ImplementsJSONMarshaler struct {
+	Output []byte
+	Err    error
+}
+
+func (i ImplementsJSONMarshaler) MarshalJSON() ([]byte, error) {
+	return i.Output, i.Err
+}
+
+type ImplementsJSONUnmarshaler struct {
+	HasBeenSet bool
+}
+
+func (i *ImplementsJSONUnmarshaler) UnmarshalJSON(input []byte) error {
+	if string(input) == `"fail"` {
+		return errors.New("ouch")
+	}
+	i.HasBeenSet = true
+	return nil
+}
+
+type RecordsJSON struct {
+	Input []byte
+}
+
+func (r *RecordsJSON) UnmarshalJSON(input []byte) error {
+	r.Input = append([]byte(nil), input...)
+	return nil
+}
+
+type ImplementsOmissible string
+
+func (i ImplementsOmissible) OmitJSONry() bool {
+	return i == "omit"
+}
+
+type OmitsNil struct{}
+
+func (o *OmitsNil) OmitJSONry() bool {
+	return o == nil
+}
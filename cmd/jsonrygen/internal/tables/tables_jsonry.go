@@ -0,0 +1,2541 @@
+// Code generated by "jsonrygen -type=Basic,FieldName,JSONTag,ListHintPath,NestedPath,String,Bool,Int,Int8,Int16,Int32,Int64,Uint,Uint8,Uint16,Uint32,Uint64,Float32,Float64,SizedInts,SizedUints,Floats,NestedSmall,PointerInt8,IntUint,Complex64,Complex128,Chan,Func,Pointer,NilInterface,NilPointer,Private,Interfaces,BasicPointers,Array,ArrayPointer,StringArray,Slice,SlicePointer,Strings,StringsPointer,Ints,IntsPointer,Map,MapPointer,MapString,MapStringPointer,MapInt,MapIntPointer,MapIntKeys,MapStringy,Bytes,BytesPointer,Base64URL,Hex,HexLists,NamedBytes,ByteArray,JSONMarshaler,JSONMarshalerPointer,JSONUnmarshaler,RecordsJSONs,RawMessages,RawMessageLists,Named,Nulls,NullStruct,NullPointer,NullInterface,InStruct,InPointer,InSlice,InMap,OmitEmptyTags,OmitFalse,OmitZero,OmitNilPointers,OmitNilInterfaces,OmitEmptyArray,OmitEmptySlices,OmitEmptyMaps,OmitEmptyStrings,OmitEmptyStruct,OmitAlways,Dash,Omissible,OmissibleOverride,OmitsNilPointers,OmissiblePointers,SharedPrefix,PrefixField,Repeated,Escapes,Remain,RemainMerge,RemainNotMap,RemainRaw,RemainNested -output=tables_jsonry.go"; DO NOT EDIT.
+
+package tables
+
+import (
+	"code.cloudfoundry.org/jsonry"
+	"code.cloudfoundry.org/jsonry/gen"
+)
+
+var jsonryBasic = gen.NewPlan(
+	gen.Field{Name: "Foo"},
+)
+
+// MarshalJSON converts the Basic into JSON in the same way as jsonry.Marshal()
+func (x Basic) MarshalJSON() ([]byte, error) {
+	w := jsonryBasic.Writer()
+	w.Key("Foo")
+	w.String(x.Foo)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Basic from JSON in the same way as jsonry.Unmarshal()
+func (x *Basic) UnmarshalJSON(data []byte) error {
+	r, err := jsonryBasic.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.String(r, 0, &x.Foo)
+	return r.Err()
+}
+
+var jsonryFieldName = gen.NewPlan(
+	gen.Field{Name: "GUID"},
+)
+
+// MarshalJSON converts the FieldName into JSON in the same way as jsonry.Marshal()
+func (x FieldName) MarshalJSON() ([]byte, error) {
+	w := jsonryFieldName.Writer()
+	w.Key("GUID")
+	w.String(x.GUID)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the FieldName from JSON in the same way as jsonry.Unmarshal()
+func (x *FieldName) UnmarshalJSON(data []byte) error {
+	r, err := jsonryFieldName.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.String(r, 0, &x.GUID)
+	return r.Err()
+}
+
+var jsonryJSONTag = gen.NewPlan(
+	gen.Field{Name: "GUID", Tag: `json:"guid"`},
+)
+
+// MarshalJSON converts the JSONTag into JSON in the same way as jsonry.Marshal()
+func (x JSONTag) MarshalJSON() ([]byte, error) {
+	w := jsonryJSONTag.Writer()
+	w.Key("guid")
+	w.String(x.GUID)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the JSONTag from JSON in the same way as jsonry.Unmarshal()
+func (x *JSONTag) UnmarshalJSON(data []byte) error {
+	r, err := jsonryJSONTag.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.String(r, 0, &x.GUID)
+	return r.Err()
+}
+
+var jsonryListHintPath = gen.NewPlan(
+	gen.Field{Name: "GUID", Tag: `jsonry:"relationships.spaces[].guid"`},
+)
+
+// MarshalJSON converts the ListHintPath into JSON in the same way as jsonry.Marshal()
+func (x ListHintPath) MarshalJSON() ([]byte, error) {
+	w := jsonryListHintPath.Writer()
+	w.Object("relationships")
+	w.Key("spaces")
+	w.Spread(0, &x.GUID)
+	w.End()
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the ListHintPath from JSON in the same way as jsonry.Unmarshal()
+func (x *ListHintPath) UnmarshalJSON(data []byte) error {
+	r, err := jsonryListHintPath.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.String(r, 0, &x.GUID)
+	return r.Err()
+}
+
+var jsonryNestedPath = gen.NewPlan(
+	gen.Field{Name: "GUID", Tag: `jsonry:"relationships.spaces.guid"`},
+)
+
+// MarshalJSON converts the NestedPath into JSON in the same way as jsonry.Marshal()
+func (x NestedPath) MarshalJSON() ([]byte, error) {
+	w := jsonryNestedPath.Writer()
+	w.Object("relationships")
+	w.Object("spaces")
+	w.Key("guid")
+	w.String(x.GUID)
+	w.End()
+	w.End()
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the NestedPath from JSON in the same way as jsonry.Unmarshal()
+func (x *NestedPath) UnmarshalJSON(data []byte) error {
+	r, err := jsonryNestedPath.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.String(r, 0, &x.GUID)
+	return r.Err()
+}
+
+var jsonryString = gen.NewPlan(
+	gen.Field{Name: "S"},
+)
+
+// MarshalJSON converts the String into JSON in the same way as jsonry.Marshal()
+func (x String) MarshalJSON() ([]byte, error) {
+	w := jsonryString.Writer()
+	w.Key("S")
+	w.String(x.S)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the String from JSON in the same way as jsonry.Unmarshal()
+func (x *String) UnmarshalJSON(data []byte) error {
+	r, err := jsonryString.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.String(r, 0, &x.S)
+	return r.Err()
+}
+
+var jsonryBool = gen.NewPlan(
+	gen.Field{Name: "T"},
+	gen.Field{Name: "F"},
+)
+
+// MarshalJSON converts the Bool into JSON in the same way as jsonry.Marshal()
+func (x Bool) MarshalJSON() ([]byte, error) {
+	w := jsonryBool.Writer()
+	w.Key("F")
+	w.Bool(x.F)
+	w.Key("T")
+	w.Bool(x.T)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Bool from JSON in the same way as jsonry.Unmarshal()
+func (x *Bool) UnmarshalJSON(data []byte) error {
+	r, err := jsonryBool.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.Bool(r, 0, &x.T)
+	gen.Bool(r, 1, &x.F)
+	return r.Err()
+}
+
+var jsonryInt = gen.NewPlan(
+	gen.Field{Name: "I"},
+)
+
+// MarshalJSON converts the Int into JSON in the same way as jsonry.Marshal()
+func (x Int) MarshalJSON() ([]byte, error) {
+	w := jsonryInt.Writer()
+	w.Key("I")
+	w.Int(int64(x.I))
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Int from JSON in the same way as jsonry.Unmarshal()
+func (x *Int) UnmarshalJSON(data []byte) error {
+	r, err := jsonryInt.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.Int(r, 0, &x.I)
+	return r.Err()
+}
+
+var jsonryInt8 = gen.NewPlan(
+	gen.Field{Name: "I"},
+)
+
+// MarshalJSON converts the Int8 into JSON in the same way as jsonry.Marshal()
+func (x Int8) MarshalJSON() ([]byte, error) {
+	w := jsonryInt8.Writer()
+	w.Key("I")
+	w.Int(int64(x.I))
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Int8 from JSON in the same way as jsonry.Unmarshal()
+func (x *Int8) UnmarshalJSON(data []byte) error {
+	r, err := jsonryInt8.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.Int(r, 0, &x.I)
+	return r.Err()
+}
+
+var jsonryInt16 = gen.NewPlan(
+	gen.Field{Name: "I"},
+)
+
+// MarshalJSON converts the Int16 into JSON in the same way as jsonry.Marshal()
+func (x Int16) MarshalJSON() ([]byte, error) {
+	w := jsonryInt16.Writer()
+	w.Key("I")
+	w.Int(int64(x.I))
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Int16 from JSON in the same way as jsonry.Unmarshal()
+func (x *Int16) UnmarshalJSON(data []byte) error {
+	r, err := jsonryInt16.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.Int(r, 0, &x.I)
+	return r.Err()
+}
+
+var jsonryInt32 = gen.NewPlan(
+	gen.Field{Name: "I"},
+)
+
+// MarshalJSON converts the Int32 into JSON in the same way as jsonry.Marshal()
+func (x Int32) MarshalJSON() ([]byte, error) {
+	w := jsonryInt32.Writer()
+	w.Key("I")
+	w.Int(int64(x.I))
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Int32 from JSON in the same way as jsonry.Unmarshal()
+func (x *Int32) UnmarshalJSON(data []byte) error {
+	r, err := jsonryInt32.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.Int(r, 0, &x.I)
+	return r.Err()
+}
+
+var jsonryInt64 = gen.NewPlan(
+	gen.Field{Name: "I"},
+)
+
+// MarshalJSON converts the Int64 into JSON in the same way as jsonry.Marshal()
+func (x Int64) MarshalJSON() ([]byte, error) {
+	w := jsonryInt64.Writer()
+	w.Key("I")
+	w.Int(x.I)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Int64 from JSON in the same way as jsonry.Unmarshal()
+func (x *Int64) UnmarshalJSON(data []byte) error {
+	r, err := jsonryInt64.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.Int(r, 0, &x.I)
+	return r.Err()
+}
+
+var jsonryUint = gen.NewPlan(
+	gen.Field{Name: "I"},
+)
+
+// MarshalJSON converts the Uint into JSON in the same way as jsonry.Marshal()
+func (x Uint) MarshalJSON() ([]byte, error) {
+	w := jsonryUint.Writer()
+	w.Key("I")
+	w.Uint(uint64(x.I))
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Uint from JSON in the same way as jsonry.Unmarshal()
+func (x *Uint) UnmarshalJSON(data []byte) error {
+	r, err := jsonryUint.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.Uint(r, 0, &x.I)
+	return r.Err()
+}
+
+var jsonryUint8 = gen.NewPlan(
+	gen.Field{Name: "I"},
+)
+
+// MarshalJSON converts the Uint8 into JSON in the same way as jsonry.Marshal()
+func (x Uint8) MarshalJSON() ([]byte, error) {
+	w := jsonryUint8.Writer()
+	w.Key("I")
+	w.Uint(uint64(x.I))
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Uint8 from JSON in the same way as jsonry.Unmarshal()
+func (x *Uint8) UnmarshalJSON(data []byte) error {
+	r, err := jsonryUint8.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.Uint(r, 0, &x.I)
+	return r.Err()
+}
+
+var jsonryUint16 = gen.NewPlan(
+	gen.Field{Name: "I"},
+)
+
+// MarshalJSON converts the Uint16 into JSON in the same way as jsonry.Marshal()
+func (x Uint16) MarshalJSON() ([]byte, error) {
+	w := jsonryUint16.Writer()
+	w.Key("I")
+	w.Uint(uint64(x.I))
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Uint16 from JSON in the same way as jsonry.Unmarshal()
+func (x *Uint16) UnmarshalJSON(data []byte) error {
+	r, err := jsonryUint16.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.Uint(r, 0, &x.I)
+	return r.Err()
+}
+
+var jsonryUint32 = gen.NewPlan(
+	gen.Field{Name: "I"},
+)
+
+// MarshalJSON converts the Uint32 into JSON in the same way as jsonry.Marshal()
+func (x Uint32) MarshalJSON() ([]byte, error) {
+	w := jsonryUint32.Writer()
+	w.Key("I")
+	w.Uint(uint64(x.I))
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Uint32 from JSON in the same way as jsonry.Unmarshal()
+func (x *Uint32) UnmarshalJSON(data []byte) error {
+	r, err := jsonryUint32.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.Uint(r, 0, &x.I)
+	return r.Err()
+}
+
+var jsonryUint64 = gen.NewPlan(
+	gen.Field{Name: "I"},
+)
+
+// MarshalJSON converts the Uint64 into JSON in the same way as jsonry.Marshal()
+func (x Uint64) MarshalJSON() ([]byte, error) {
+	w := jsonryUint64.Writer()
+	w.Key("I")
+	w.Uint(x.I)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Uint64 from JSON in the same way as jsonry.Unmarshal()
+func (x *Uint64) UnmarshalJSON(data []byte) error {
+	r, err := jsonryUint64.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.Uint(r, 0, &x.I)
+	return r.Err()
+}
+
+var jsonryFloat32 = gen.NewPlan(
+	gen.Field{Name: "E"},
+	gen.Field{Name: "F"},
+)
+
+// MarshalJSON converts the Float32 into JSON in the same way as jsonry.Marshal()
+func (x Float32) MarshalJSON() ([]byte, error) {
+	w := jsonryFloat32.Writer()
+	w.Key("E")
+	w.Float32(x.E)
+	w.Key("F")
+	w.Float32(x.F)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Float32 from JSON in the same way as jsonry.Unmarshal()
+func (x *Float32) UnmarshalJSON(data []byte) error {
+	r, err := jsonryFloat32.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.Float(r, 0, &x.E)
+	gen.Float(r, 1, &x.F)
+	return r.Err()
+}
+
+var jsonryFloat64 = gen.NewPlan(
+	gen.Field{Name: "E"},
+	gen.Field{Name: "F"},
+)
+
+// MarshalJSON converts the Float64 into JSON in the same way as jsonry.Marshal()
+func (x Float64) MarshalJSON() ([]byte, error) {
+	w := jsonryFloat64.Writer()
+	w.Key("E")
+	w.Float64(x.E)
+	w.Key("F")
+	w.Float64(x.F)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Float64 from JSON in the same way as jsonry.Unmarshal()
+func (x *Float64) UnmarshalJSON(data []byte) error {
+	r, err := jsonryFloat64.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.Float(r, 0, &x.E)
+	gen.Float(r, 1, &x.F)
+	return r.Err()
+}
+
+var jsonrySizedInts = gen.NewPlan(
+	gen.Field{Name: "I8"},
+	gen.Field{Name: "I16"},
+	gen.Field{Name: "I32"},
+	gen.Field{Name: "I64"},
+)
+
+// MarshalJSON converts the SizedInts into JSON in the same way as jsonry.Marshal()
+func (x SizedInts) MarshalJSON() ([]byte, error) {
+	w := jsonrySizedInts.Writer()
+	w.Key("I16")
+	w.Int(int64(x.I16))
+	w.Key("I32")
+	w.Int(int64(x.I32))
+	w.Key("I64")
+	w.Int(x.I64)
+	w.Key("I8")
+	w.Int(int64(x.I8))
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the SizedInts from JSON in the same way as jsonry.Unmarshal()
+func (x *SizedInts) UnmarshalJSON(data []byte) error {
+	r, err := jsonrySizedInts.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.Int(r, 0, &x.I8)
+	gen.Int(r, 1, &x.I16)
+	gen.Int(r, 2, &x.I32)
+	gen.Int(r, 3, &x.I64)
+	return r.Err()
+}
+
+var jsonrySizedUints = gen.NewPlan(
+	gen.Field{Name: "U8"},
+	gen.Field{Name: "U64"},
+)
+
+// MarshalJSON converts the SizedUints into JSON in the same way as jsonry.Marshal()
+func (x SizedUints) MarshalJSON() ([]byte, error) {
+	w := jsonrySizedUints.Writer()
+	w.Key("U64")
+	w.Uint(x.U64)
+	w.Key("U8")
+	w.Uint(uint64(x.U8))
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the SizedUints from JSON in the same way as jsonry.Unmarshal()
+func (x *SizedUints) UnmarshalJSON(data []byte) error {
+	r, err := jsonrySizedUints.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.Uint(r, 0, &x.U8)
+	gen.Uint(r, 1, &x.U64)
+	return r.Err()
+}
+
+var jsonryFloats = gen.NewPlan(
+	gen.Field{Name: "F32"},
+	gen.Field{Name: "F64"},
+)
+
+// MarshalJSON converts the Floats into JSON in the same way as jsonry.Marshal()
+func (x Floats) MarshalJSON() ([]byte, error) {
+	w := jsonryFloats.Writer()
+	w.Key("F32")
+	w.Float32(x.F32)
+	w.Key("F64")
+	w.Float64(x.F64)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Floats from JSON in the same way as jsonry.Unmarshal()
+func (x *Floats) UnmarshalJSON(data []byte) error {
+	r, err := jsonryFloats.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.Float(r, 0, &x.F32)
+	gen.Float(r, 1, &x.F64)
+	return r.Err()
+}
+
+var jsonryNestedSmall = gen.NewPlan(
+	gen.Field{Name: "L", Tag: `jsonry:"a.b"`},
+)
+
+// MarshalJSON converts the NestedSmall into JSON in the same way as jsonry.Marshal()
+func (x NestedSmall) MarshalJSON() ([]byte, error) {
+	w := jsonryNestedSmall.Writer()
+	w.Object("a")
+	w.Key("b")
+	w.Value(0, &x.L)
+	w.End()
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the NestedSmall from JSON in the same way as jsonry.Unmarshal()
+func (x *NestedSmall) UnmarshalJSON(data []byte) error {
+	r, err := jsonryNestedSmall.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.L)
+	return r.Err()
+}
+
+var jsonryPointerInt8 = gen.NewPlan(
+	gen.Field{Name: "P"},
+)
+
+// MarshalJSON converts the PointerInt8 into JSON in the same way as jsonry.Marshal()
+func (x PointerInt8) MarshalJSON() ([]byte, error) {
+	w := jsonryPointerInt8.Writer()
+	w.Key("P")
+	w.Value(0, &x.P)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the PointerInt8 from JSON in the same way as jsonry.Unmarshal()
+func (x *PointerInt8) UnmarshalJSON(data []byte) error {
+	r, err := jsonryPointerInt8.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.P)
+	return r.Err()
+}
+
+var jsonryIntUint = gen.NewPlan(
+	gen.Field{Name: "I"},
+	gen.Field{Name: "U"},
+)
+
+// MarshalJSON converts the IntUint into JSON in the same way as jsonry.Marshal()
+func (x IntUint) MarshalJSON() ([]byte, error) {
+	w := jsonryIntUint.Writer()
+	w.Key("I")
+	w.Int(int64(x.I))
+	w.Key("U")
+	w.Uint(uint64(x.U))
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the IntUint from JSON in the same way as jsonry.Unmarshal()
+func (x *IntUint) UnmarshalJSON(data []byte) error {
+	r, err := jsonryIntUint.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.Int(r, 0, &x.I)
+	gen.Uint(r, 1, &x.U)
+	return r.Err()
+}
+
+var jsonryComplex64 = gen.NewPlan(
+	gen.Field{Name: "C"},
+)
+
+// MarshalJSON converts the Complex64 into JSON in the same way as jsonry.Marshal()
+func (x Complex64) MarshalJSON() ([]byte, error) {
+	w := jsonryComplex64.Writer()
+	w.Key("C")
+	w.Value(0, &x.C)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Complex64 from JSON in the same way as jsonry.Unmarshal()
+func (x *Complex64) UnmarshalJSON(data []byte) error {
+	r, err := jsonryComplex64.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.C)
+	return r.Err()
+}
+
+var jsonryComplex128 = gen.NewPlan(
+	gen.Field{Name: "C"},
+)
+
+// MarshalJSON converts the Complex128 into JSON in the same way as jsonry.Marshal()
+func (x Complex128) MarshalJSON() ([]byte, error) {
+	w := jsonryComplex128.Writer()
+	w.Key("C")
+	w.Value(0, &x.C)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Complex128 from JSON in the same way as jsonry.Unmarshal()
+func (x *Complex128) UnmarshalJSON(data []byte) error {
+	r, err := jsonryComplex128.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.C)
+	return r.Err()
+}
+
+var jsonryChan = gen.NewPlan(
+	gen.Field{Name: "C"},
+)
+
+// MarshalJSON converts the Chan into JSON in the same way as jsonry.Marshal()
+func (x Chan) MarshalJSON() ([]byte, error) {
+	w := jsonryChan.Writer()
+	w.Key("C")
+	w.Value(0, &x.C)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Chan from JSON in the same way as jsonry.Unmarshal()
+func (x *Chan) UnmarshalJSON(data []byte) error {
+	r, err := jsonryChan.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.C)
+	return r.Err()
+}
+
+var jsonryFunc = gen.NewPlan(
+	gen.Field{Name: "F"},
+)
+
+// MarshalJSON converts the Func into JSON in the same way as jsonry.Marshal()
+func (x Func) MarshalJSON() ([]byte, error) {
+	w := jsonryFunc.Writer()
+	w.Key("F")
+	w.Value(0, &x.F)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Func from JSON in the same way as jsonry.Unmarshal()
+func (x *Func) UnmarshalJSON(data []byte) error {
+	r, err := jsonryFunc.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.F)
+	return r.Err()
+}
+
+var jsonryPointer = gen.NewPlan(
+	gen.Field{Name: "P"},
+)
+
+// MarshalJSON converts the Pointer into JSON in the same way as jsonry.Marshal()
+func (x Pointer) MarshalJSON() ([]byte, error) {
+	w := jsonryPointer.Writer()
+	w.Key("P")
+	w.Value(0, &x.P)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Pointer from JSON in the same way as jsonry.Unmarshal()
+func (x *Pointer) UnmarshalJSON(data []byte) error {
+	r, err := jsonryPointer.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.P)
+	return r.Err()
+}
+
+var jsonryNilInterface = gen.NewPlan(
+	gen.Field{Name: "N"},
+)
+
+// MarshalJSON converts the NilInterface into JSON in the same way as jsonry.Marshal()
+func (x NilInterface) MarshalJSON() ([]byte, error) {
+	w := jsonryNilInterface.Writer()
+	w.Key("N")
+	w.Value(0, &x.N)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the NilInterface from JSON in the same way as jsonry.Unmarshal()
+func (x *NilInterface) UnmarshalJSON(data []byte) error {
+	r, err := jsonryNilInterface.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.N)
+	return r.Err()
+}
+
+var jsonryNilPointer = gen.NewPlan(
+	gen.Field{Name: "N"},
+)
+
+// MarshalJSON converts the NilPointer into JSON in the same way as jsonry.Marshal()
+func (x NilPointer) MarshalJSON() ([]byte, error) {
+	w := jsonryNilPointer.Writer()
+	w.Key("N")
+	w.Value(0, &x.N)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the NilPointer from JSON in the same way as jsonry.Unmarshal()
+func (x *NilPointer) UnmarshalJSON(data []byte) error {
+	r, err := jsonryNilPointer.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.N)
+	return r.Err()
+}
+
+var jsonryPrivate = gen.NewPlan(
+	gen.Field{Name: "P"},
+)
+
+// MarshalJSON converts the Private into JSON in the same way as jsonry.Marshal()
+func (x Private) MarshalJSON() ([]byte, error) {
+	w := jsonryPrivate.Writer()
+	w.Key("P")
+	w.String(x.P)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Private from JSON in the same way as jsonry.Unmarshal()
+func (x *Private) UnmarshalJSON(data []byte) error {
+	r, err := jsonryPrivate.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.String(r, 0, &x.P)
+	return r.Err()
+}
+
+var jsonryInterfaces = gen.NewPlan(
+	gen.Field{Name: "N"},
+	gen.Field{Name: "B"},
+	gen.Field{Name: "S"},
+	gen.Field{Name: "I"},
+	gen.Field{Name: "U"},
+	gen.Field{Name: "F"},
+	gen.Field{Name: "L"},
+	gen.Field{Name: "M"},
+)
+
+// MarshalJSON converts the Interfaces into JSON in the same way as jsonry.Marshal()
+func (x Interfaces) MarshalJSON() ([]byte, error) {
+	w := jsonryInterfaces.Writer()
+	w.Key("B")
+	w.Value(1, &x.B)
+	w.Key("F")
+	w.Value(5, &x.F)
+	w.Key("I")
+	w.Value(3, &x.I)
+	w.Key("L")
+	w.Value(6, &x.L)
+	w.Key("M")
+	w.Value(7, &x.M)
+	w.Key("N")
+	w.Value(0, &x.N)
+	w.Key("S")
+	w.Value(2, &x.S)
+	w.Key("U")
+	w.Value(4, &x.U)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Interfaces from JSON in the same way as jsonry.Unmarshal()
+func (x *Interfaces) UnmarshalJSON(data []byte) error {
+	r, err := jsonryInterfaces.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.N)
+	r.Value(1, &x.B)
+	r.Value(2, &x.S)
+	r.Value(3, &x.I)
+	r.Value(4, &x.U)
+	r.Value(5, &x.F)
+	r.Value(6, &x.L)
+	r.Value(7, &x.M)
+	return r.Err()
+}
+
+var jsonryBasicPointers = gen.NewPlan(
+	gen.Field{Name: "S"},
+	gen.Field{Name: "T"},
+	gen.Field{Name: "I"},
+	gen.Field{Name: "J"},
+)
+
+// MarshalJSON converts the BasicPointers into JSON in the same way as jsonry.Marshal()
+func (x BasicPointers) MarshalJSON() ([]byte, error) {
+	w := jsonryBasicPointers.Writer()
+	w.Key("I")
+	w.Value(2, &x.I)
+	w.Key("J")
+	w.Value(3, &x.J)
+	w.Key("S")
+	w.Value(0, &x.S)
+	w.Key("T")
+	w.Value(1, &x.T)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the BasicPointers from JSON in the same way as jsonry.Unmarshal()
+func (x *BasicPointers) UnmarshalJSON(data []byte) error {
+	r, err := jsonryBasicPointers.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.S)
+	r.Value(1, &x.T)
+	r.Value(2, &x.I)
+	r.Value(3, &x.J)
+	return r.Err()
+}
+
+var jsonryArray = gen.NewPlan(
+	gen.Field{Name: "S"},
+)
+
+// MarshalJSON converts the Array into JSON in the same way as jsonry.Marshal()
+func (x Array) MarshalJSON() ([]byte, error) {
+	w := jsonryArray.Writer()
+	w.Key("S")
+	w.Value(0, &x.S)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Array from JSON in the same way as jsonry.Unmarshal()
+func (x *Array) UnmarshalJSON(data []byte) error {
+	r, err := jsonryArray.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.S)
+	return r.Err()
+}
+
+var jsonryArrayPointer = gen.NewPlan(
+	gen.Field{Name: "S"},
+)
+
+// MarshalJSON converts the ArrayPointer into JSON in the same way as jsonry.Marshal()
+func (x ArrayPointer) MarshalJSON() ([]byte, error) {
+	w := jsonryArrayPointer.Writer()
+	w.Key("S")
+	w.Value(0, &x.S)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the ArrayPointer from JSON in the same way as jsonry.Unmarshal()
+func (x *ArrayPointer) UnmarshalJSON(data []byte) error {
+	r, err := jsonryArrayPointer.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.S)
+	return r.Err()
+}
+
+var jsonryStringArray = gen.NewPlan(
+	gen.Field{Name: "S"},
+)
+
+// MarshalJSON converts the StringArray into JSON in the same way as jsonry.Marshal()
+func (x StringArray) MarshalJSON() ([]byte, error) {
+	w := jsonryStringArray.Writer()
+	w.Key("S")
+	w.Value(0, &x.S)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the StringArray from JSON in the same way as jsonry.Unmarshal()
+func (x *StringArray) UnmarshalJSON(data []byte) error {
+	r, err := jsonryStringArray.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.S)
+	return r.Err()
+}
+
+var jsonrySlice = gen.NewPlan(
+	gen.Field{Name: "S"},
+)
+
+// MarshalJSON converts the Slice into JSON in the same way as jsonry.Marshal()
+func (x Slice) MarshalJSON() ([]byte, error) {
+	w := jsonrySlice.Writer()
+	w.Key("S")
+	w.Value(0, &x.S)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Slice from JSON in the same way as jsonry.Unmarshal()
+func (x *Slice) UnmarshalJSON(data []byte) error {
+	r, err := jsonrySlice.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.S)
+	return r.Err()
+}
+
+var jsonrySlicePointer = gen.NewPlan(
+	gen.Field{Name: "S"},
+)
+
+// MarshalJSON converts the SlicePointer into JSON in the same way as jsonry.Marshal()
+func (x SlicePointer) MarshalJSON() ([]byte, error) {
+	w := jsonrySlicePointer.Writer()
+	w.Key("S")
+	w.Value(0, &x.S)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the SlicePointer from JSON in the same way as jsonry.Unmarshal()
+func (x *SlicePointer) UnmarshalJSON(data []byte) error {
+	r, err := jsonrySlicePointer.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.S)
+	return r.Err()
+}
+
+var jsonryStrings = gen.NewPlan(
+	gen.Field{Name: "S"},
+)
+
+// MarshalJSON converts the Strings into JSON in the same way as jsonry.Marshal()
+func (x Strings) MarshalJSON() ([]byte, error) {
+	w := jsonryStrings.Writer()
+	w.Key("S")
+	w.Value(0, &x.S)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Strings from JSON in the same way as jsonry.Unmarshal()
+func (x *Strings) UnmarshalJSON(data []byte) error {
+	r, err := jsonryStrings.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.S)
+	return r.Err()
+}
+
+var jsonryStringsPointer = gen.NewPlan(
+	gen.Field{Name: "S"},
+)
+
+// MarshalJSON converts the StringsPointer into JSON in the same way as jsonry.Marshal()
+func (x StringsPointer) MarshalJSON() ([]byte, error) {
+	w := jsonryStringsPointer.Writer()
+	w.Key("S")
+	w.Value(0, &x.S)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the StringsPointer from JSON in the same way as jsonry.Unmarshal()
+func (x *StringsPointer) UnmarshalJSON(data []byte) error {
+	r, err := jsonryStringsPointer.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.S)
+	return r.Err()
+}
+
+var jsonryInts = gen.NewPlan(
+	gen.Field{Name: "N"},
+)
+
+// MarshalJSON converts the Ints into JSON in the same way as jsonry.Marshal()
+func (x Ints) MarshalJSON() ([]byte, error) {
+	w := jsonryInts.Writer()
+	w.Key("N")
+	w.Value(0, &x.N)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Ints from JSON in the same way as jsonry.Unmarshal()
+func (x *Ints) UnmarshalJSON(data []byte) error {
+	r, err := jsonryInts.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.N)
+	return r.Err()
+}
+
+var jsonryIntsPointer = gen.NewPlan(
+	gen.Field{Name: "N"},
+)
+
+// MarshalJSON converts the IntsPointer into JSON in the same way as jsonry.Marshal()
+func (x IntsPointer) MarshalJSON() ([]byte, error) {
+	w := jsonryIntsPointer.Writer()
+	w.Key("N")
+	w.Value(0, &x.N)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the IntsPointer from JSON in the same way as jsonry.Unmarshal()
+func (x *IntsPointer) UnmarshalJSON(data []byte) error {
+	r, err := jsonryIntsPointer.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.N)
+	return r.Err()
+}
+
+var jsonryMap = gen.NewPlan(
+	gen.Field{Name: "M"},
+)
+
+// MarshalJSON converts the Map into JSON in the same way as jsonry.Marshal()
+func (x Map) MarshalJSON() ([]byte, error) {
+	w := jsonryMap.Writer()
+	w.Key("M")
+	w.Value(0, &x.M)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Map from JSON in the same way as jsonry.Unmarshal()
+func (x *Map) UnmarshalJSON(data []byte) error {
+	r, err := jsonryMap.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.M)
+	return r.Err()
+}
+
+var jsonryMapPointer = gen.NewPlan(
+	gen.Field{Name: "M"},
+)
+
+// MarshalJSON converts the MapPointer into JSON in the same way as jsonry.Marshal()
+func (x MapPointer) MarshalJSON() ([]byte, error) {
+	w := jsonryMapPointer.Writer()
+	w.Key("M")
+	w.Value(0, &x.M)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the MapPointer from JSON in the same way as jsonry.Unmarshal()
+func (x *MapPointer) UnmarshalJSON(data []byte) error {
+	r, err := jsonryMapPointer.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.M)
+	return r.Err()
+}
+
+var jsonryMapString = gen.NewPlan(
+	gen.Field{Name: "M"},
+)
+
+// MarshalJSON converts the MapString into JSON in the same way as jsonry.Marshal()
+func (x MapString) MarshalJSON() ([]byte, error) {
+	w := jsonryMapString.Writer()
+	w.Key("M")
+	w.Value(0, &x.M)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the MapString from JSON in the same way as jsonry.Unmarshal()
+func (x *MapString) UnmarshalJSON(data []byte) error {
+	r, err := jsonryMapString.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.M)
+	return r.Err()
+}
+
+var jsonryMapStringPointer = gen.NewPlan(
+	gen.Field{Name: "M"},
+)
+
+// MarshalJSON converts the MapStringPointer into JSON in the same way as jsonry.Marshal()
+func (x MapStringPointer) MarshalJSON() ([]byte, error) {
+	w := jsonryMapStringPointer.Writer()
+	w.Key("M")
+	w.Value(0, &x.M)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the MapStringPointer from JSON in the same way as jsonry.Unmarshal()
+func (x *MapStringPointer) UnmarshalJSON(data []byte) error {
+	r, err := jsonryMapStringPointer.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.M)
+	return r.Err()
+}
+
+var jsonryMapInt = gen.NewPlan(
+	gen.Field{Name: "N"},
+)
+
+// MarshalJSON converts the MapInt into JSON in the same way as jsonry.Marshal()
+func (x MapInt) MarshalJSON() ([]byte, error) {
+	w := jsonryMapInt.Writer()
+	w.Key("N")
+	w.Value(0, &x.N)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the MapInt from JSON in the same way as jsonry.Unmarshal()
+func (x *MapInt) UnmarshalJSON(data []byte) error {
+	r, err := jsonryMapInt.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.N)
+	return r.Err()
+}
+
+var jsonryMapIntPointer = gen.NewPlan(
+	gen.Field{Name: "N"},
+)
+
+// MarshalJSON converts the MapIntPointer into JSON in the same way as jsonry.Marshal()
+func (x MapIntPointer) MarshalJSON() ([]byte, error) {
+	w := jsonryMapIntPointer.Writer()
+	w.Key("N")
+	w.Value(0, &x.N)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the MapIntPointer from JSON in the same way as jsonry.Unmarshal()
+func (x *MapIntPointer) UnmarshalJSON(data []byte) error {
+	r, err := jsonryMapIntPointer.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.N)
+	return r.Err()
+}
+
+var jsonryMapIntKeys = gen.NewPlan(
+	gen.Field{Name: "M"},
+)
+
+// MarshalJSON converts the MapIntKeys into JSON in the same way as jsonry.Marshal()
+func (x MapIntKeys) MarshalJSON() ([]byte, error) {
+	w := jsonryMapIntKeys.Writer()
+	w.Key("M")
+	w.Value(0, &x.M)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the MapIntKeys from JSON in the same way as jsonry.Unmarshal()
+func (x *MapIntKeys) UnmarshalJSON(data []byte) error {
+	r, err := jsonryMapIntKeys.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.M)
+	return r.Err()
+}
+
+var jsonryMapStringy = gen.NewPlan(
+	gen.Field{Name: "M"},
+)
+
+// MarshalJSON converts the MapStringy into JSON in the same way as jsonry.Marshal()
+func (x MapStringy) MarshalJSON() ([]byte, error) {
+	w := jsonryMapStringy.Writer()
+	w.Key("M")
+	w.Value(0, &x.M)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the MapStringy from JSON in the same way as jsonry.Unmarshal()
+func (x *MapStringy) UnmarshalJSON(data []byte) error {
+	r, err := jsonryMapStringy.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.M)
+	return r.Err()
+}
+
+var jsonryBytes = gen.NewPlan(
+	gen.Field{Name: "B"},
+)
+
+// MarshalJSON converts the Bytes into JSON in the same way as jsonry.Marshal()
+func (x Bytes) MarshalJSON() ([]byte, error) {
+	w := jsonryBytes.Writer()
+	w.Key("B")
+	w.Value(0, &x.B)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Bytes from JSON in the same way as jsonry.Unmarshal()
+func (x *Bytes) UnmarshalJSON(data []byte) error {
+	r, err := jsonryBytes.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.B)
+	return r.Err()
+}
+
+var jsonryBytesPointer = gen.NewPlan(
+	gen.Field{Name: "B"},
+)
+
+// MarshalJSON converts the BytesPointer into JSON in the same way as jsonry.Marshal()
+func (x BytesPointer) MarshalJSON() ([]byte, error) {
+	w := jsonryBytesPointer.Writer()
+	w.Key("B")
+	w.Value(0, &x.B)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the BytesPointer from JSON in the same way as jsonry.Unmarshal()
+func (x *BytesPointer) UnmarshalJSON(data []byte) error {
+	r, err := jsonryBytesPointer.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.B)
+	return r.Err()
+}
+
+var jsonryBase64URL = gen.NewPlan(
+	gen.Field{Name: "B", Tag: `jsonry:"b,base64url"`},
+	gen.Field{Name: "C", Tag: `jsonry:"c,base64url"`},
+)
+
+// MarshalJSON converts the Base64URL into JSON in the same way as jsonry.Marshal()
+func (x Base64URL) MarshalJSON() ([]byte, error) {
+	w := jsonryBase64URL.Writer()
+	w.Key("b")
+	w.Value(0, &x.B)
+	w.Key("c")
+	w.Value(1, &x.C)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Base64URL from JSON in the same way as jsonry.Unmarshal()
+func (x *Base64URL) UnmarshalJSON(data []byte) error {
+	r, err := jsonryBase64URL.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.B)
+	r.Value(1, &x.C)
+	return r.Err()
+}
+
+var jsonryHex = gen.NewPlan(
+	gen.Field{Name: "B", Tag: `json:"b,hex,omitempty"`},
+	gen.Field{Name: "C", Tag: `jsonry:"c.d,omitempty,hex"`},
+)
+
+// MarshalJSON converts the Hex into JSON in the same way as jsonry.Marshal()
+func (x Hex) MarshalJSON() ([]byte, error) {
+	w := jsonryHex.Writer()
+	if len(x.B) != 0 {
+		w.Key("b")
+		w.Value(0, &x.B)
+	}
+	w.Object("c")
+	if len(x.C) != 0 {
+		w.Key("d")
+		w.Value(1, &x.C)
+	}
+	w.End()
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Hex from JSON in the same way as jsonry.Unmarshal()
+func (x *Hex) UnmarshalJSON(data []byte) error {
+	r, err := jsonryHex.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.B)
+	r.Value(1, &x.C)
+	return r.Err()
+}
+
+var jsonryHexLists = gen.NewPlan(
+	gen.Field{Name: "B", Tag: `jsonry:"a.b,hex"`},
+	gen.Field{Name: "L", Tag: `jsonry:",hex"`},
+	gen.Field{Name: "M", Tag: `jsonry:",hex"`},
+)
+
+// MarshalJSON converts the HexLists into JSON in the same way as jsonry.Marshal()
+func (x HexLists) MarshalJSON() ([]byte, error) {
+	w := jsonryHexLists.Writer()
+	w.Key("L")
+	w.Value(1, &x.L)
+	w.Key("M")
+	w.Value(2, &x.M)
+	w.Object("a")
+	w.Key("b")
+	w.Value(0, &x.B)
+	w.End()
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the HexLists from JSON in the same way as jsonry.Unmarshal()
+func (x *HexLists) UnmarshalJSON(data []byte) error {
+	r, err := jsonryHexLists.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.B)
+	r.Value(1, &x.L)
+	r.Value(2, &x.M)
+	return r.Err()
+}
+
+var jsonryNamedBytes = gen.NewPlan(
+	gen.Field{Name: "B"},
+)
+
+// MarshalJSON converts the NamedBytes into JSON in the same way as jsonry.Marshal()
+func (x NamedBytes) MarshalJSON() ([]byte, error) {
+	w := jsonryNamedBytes.Writer()
+	w.Key("B")
+	w.Value(0, &x.B)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the NamedBytes from JSON in the same way as jsonry.Unmarshal()
+func (x *NamedBytes) UnmarshalJSON(data []byte) error {
+	r, err := jsonryNamedBytes.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.B)
+	return r.Err()
+}
+
+var jsonryByteArray = gen.NewPlan(
+	gen.Field{Name: "B"},
+)
+
+// MarshalJSON converts the ByteArray into JSON in the same way as jsonry.Marshal()
+func (x ByteArray) MarshalJSON() ([]byte, error) {
+	w := jsonryByteArray.Writer()
+	w.Key("B")
+	w.Value(0, &x.B)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the ByteArray from JSON in the same way as jsonry.Unmarshal()
+func (x *ByteArray) UnmarshalJSON(data []byte) error {
+	r, err := jsonryByteArray.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.B)
+	return r.Err()
+}
+
+var jsonryJSONMarshaler = gen.NewPlan(
+	gen.Field{Name: "I"},
+)
+
+// MarshalJSON converts the JSONMarshaler into JSON in the same way as jsonry.Marshal()
+func (x JSONMarshaler) MarshalJSON() ([]byte, error) {
+	w := jsonryJSONMarshaler.Writer()
+	w.Key("I")
+	w.Value(0, &x.I)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the JSONMarshaler from JSON in the same way as jsonry.Unmarshal()
+func (x *JSONMarshaler) UnmarshalJSON(data []byte) error {
+	r, err := jsonryJSONMarshaler.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.I)
+	return r.Err()
+}
+
+var jsonryJSONMarshalerPointer = gen.NewPlan(
+	gen.Field{Name: "I"},
+)
+
+// MarshalJSON converts the JSONMarshalerPointer into JSON in the same way as jsonry.Marshal()
+func (x JSONMarshalerPointer) MarshalJSON() ([]byte, error) {
+	w := jsonryJSONMarshalerPointer.Writer()
+	w.Key("I")
+	w.Value(0, &x.I)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the JSONMarshalerPointer from JSON in the same way as jsonry.Unmarshal()
+func (x *JSONMarshalerPointer) UnmarshalJSON(data []byte) error {
+	r, err := jsonryJSONMarshalerPointer.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.I)
+	return r.Err()
+}
+
+var jsonryJSONUnmarshaler = gen.NewPlan(
+	gen.Field{Name: "S"},
+)
+
+// MarshalJSON converts the JSONUnmarshaler into JSON in the same way as jsonry.Marshal()
+func (x JSONUnmarshaler) MarshalJSON() ([]byte, error) {
+	w := jsonryJSONUnmarshaler.Writer()
+	w.Key("S")
+	w.Value(0, &x.S)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the JSONUnmarshaler from JSON in the same way as jsonry.Unmarshal()
+func (x *JSONUnmarshaler) UnmarshalJSON(data []byte) error {
+	r, err := jsonryJSONUnmarshaler.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.S)
+	return r.Err()
+}
+
+var jsonryRecordsJSONs = gen.NewPlan(
+	gen.Field{Name: "R", Tag: `jsonry:"a.b"`},
+	gen.Field{Name: "L", Tag: `jsonry:"a.c"`},
+)
+
+// MarshalJSON converts the RecordsJSONs into JSON in the same way as jsonry.Marshal()
+func (x RecordsJSONs) MarshalJSON() ([]byte, error) {
+	w := jsonryRecordsJSONs.Writer()
+	w.Object("a")
+	w.Key("b")
+	w.Value(0, &x.R)
+	w.Key("c")
+	w.Value(1, &x.L)
+	w.End()
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the RecordsJSONs from JSON in the same way as jsonry.Unmarshal()
+func (x *RecordsJSONs) UnmarshalJSON(data []byte) error {
+	r, err := jsonryRecordsJSONs.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.R)
+	r.Value(1, &x.L)
+	return r.Err()
+}
+
+var jsonryRawMessages = gen.NewPlan(
+	gen.Field{Name: "R", Tag: `jsonry:"a.b"`},
+	gen.Field{Name: "P", Tag: `jsonry:"a.c"`},
+	gen.Field{Name: "S", Tag: `jsonry:"a.d"`},
+	gen.Field{Name: "N"},
+	gen.Field{Name: "Q"},
+)
+
+// MarshalJSON converts the RawMessages into JSON in the same way as jsonry.Marshal()
+func (x RawMessages) MarshalJSON() ([]byte, error) {
+	w := jsonryRawMessages.Writer()
+	w.Key("N")
+	w.Value(3, &x.N)
+	w.Key("Q")
+	w.Value(4, &x.Q)
+	w.Object("a")
+	w.Key("b")
+	w.Value(0, &x.R)
+	w.Key("c")
+	w.Value(1, &x.P)
+	w.Key("d")
+	w.Value(2, &x.S)
+	w.End()
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the RawMessages from JSON in the same way as jsonry.Unmarshal()
+func (x *RawMessages) UnmarshalJSON(data []byte) error {
+	r, err := jsonryRawMessages.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.R)
+	r.Value(1, &x.P)
+	r.Value(2, &x.S)
+	r.Value(3, &x.N)
+	r.Value(4, &x.Q)
+	return r.Err()
+}
+
+var jsonryRawMessageLists = gen.NewPlan(
+	gen.Field{Name: "L", Tag: `jsonry:"items"`},
+	gen.Field{Name: "M", Tag: `jsonry:"items.data"`},
+)
+
+// MarshalJSON converts the RawMessageLists into JSON using jsonry.Marshal(), because the path of field "M" conflicts with another field
+func (x RawMessageLists) MarshalJSON() ([]byte, error) {
+	return jsonry.Marshal(x)
+}
+
+// UnmarshalJSON sets the RawMessageLists from JSON in the same way as jsonry.Unmarshal()
+func (x *RawMessageLists) UnmarshalJSON(data []byte) error {
+	r, err := jsonryRawMessageLists.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.L)
+	r.Value(1, &x.M)
+	return r.Err()
+}
+
+var jsonryNamed = gen.NewPlan(
+	gen.Field{Name: "A"},
+	gen.Field{Name: "N"},
+)
+
+// MarshalJSON converts the Named into JSON in the same way as jsonry.Marshal()
+func (x Named) MarshalJSON() ([]byte, error) {
+	w := jsonryNamed.Writer()
+	w.Key("A")
+	w.String(x.A)
+	w.Key("N")
+	w.String(string(x.N))
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Named from JSON in the same way as jsonry.Unmarshal()
+func (x *Named) UnmarshalJSON(data []byte) error {
+	r, err := jsonryNamed.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.String(r, 0, &x.A)
+	gen.String(r, 1, &x.N)
+	return r.Err()
+}
+
+var jsonryNulls = gen.NewPlan(
+	gen.Field{Name: "S"},
+	gen.Field{Name: "T"},
+	gen.Field{Name: "U"},
+	gen.Field{Name: "V"},
+	gen.Field{Name: "W"},
+)
+
+// MarshalJSON converts the Nulls into JSON in the same way as jsonry.Marshal()
+func (x Nulls) MarshalJSON() ([]byte, error) {
+	w := jsonryNulls.Writer()
+	w.Key("S")
+	w.String(x.S)
+	w.Key("T")
+	w.Int(int64(x.T))
+	w.Key("U")
+	w.Uint(uint64(x.U))
+	w.Key("V")
+	w.Float64(x.V)
+	w.Key("W")
+	w.Bool(x.W)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Nulls from JSON in the same way as jsonry.Unmarshal()
+func (x *Nulls) UnmarshalJSON(data []byte) error {
+	r, err := jsonryNulls.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.String(r, 0, &x.S)
+	gen.Int(r, 1, &x.T)
+	gen.Uint(r, 2, &x.U)
+	gen.Float(r, 3, &x.V)
+	gen.Bool(r, 4, &x.W)
+	return r.Err()
+}
+
+var jsonryNullStruct = gen.NewPlan(
+	gen.Field{Name: "A"},
+)
+
+// MarshalJSON converts the NullStruct into JSON in the same way as jsonry.Marshal()
+func (x NullStruct) MarshalJSON() ([]byte, error) {
+	w := jsonryNullStruct.Writer()
+	w.Key("A")
+	w.Value(0, &x.A)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the NullStruct from JSON in the same way as jsonry.Unmarshal()
+func (x *NullStruct) UnmarshalJSON(data []byte) error {
+	r, err := jsonryNullStruct.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.A)
+	return r.Err()
+}
+
+var jsonryNullPointer = gen.NewPlan(
+	gen.Field{Name: "S"},
+)
+
+// MarshalJSON converts the NullPointer into JSON in the same way as jsonry.Marshal()
+func (x NullPointer) MarshalJSON() ([]byte, error) {
+	w := jsonryNullPointer.Writer()
+	w.Key("S")
+	w.Value(0, &x.S)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the NullPointer from JSON in the same way as jsonry.Unmarshal()
+func (x *NullPointer) UnmarshalJSON(data []byte) error {
+	r, err := jsonryNullPointer.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.S)
+	return r.Err()
+}
+
+var jsonryNullInterface = gen.NewPlan(
+	gen.Field{Name: "S"},
+)
+
+// MarshalJSON converts the NullInterface into JSON in the same way as jsonry.Marshal()
+func (x NullInterface) MarshalJSON() ([]byte, error) {
+	w := jsonryNullInterface.Writer()
+	w.Key("S")
+	w.Value(0, &x.S)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the NullInterface from JSON in the same way as jsonry.Unmarshal()
+func (x *NullInterface) UnmarshalJSON(data []byte) error {
+	r, err := jsonryNullInterface.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.S)
+	return r.Err()
+}
+
+var jsonryInStruct = gen.NewPlan(
+	gen.Field{Name: "T"},
+)
+
+// MarshalJSON converts the InStruct into JSON in the same way as jsonry.Marshal()
+func (x InStruct) MarshalJSON() ([]byte, error) {
+	w := jsonryInStruct.Writer()
+	w.Key("T")
+	w.Value(0, &x.T)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the InStruct from JSON in the same way as jsonry.Unmarshal()
+func (x *InStruct) UnmarshalJSON(data []byte) error {
+	r, err := jsonryInStruct.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.T)
+	return r.Err()
+}
+
+var jsonryInPointer = gen.NewPlan(
+	gen.Field{Name: "T"},
+)
+
+// MarshalJSON converts the InPointer into JSON in the same way as jsonry.Marshal()
+func (x InPointer) MarshalJSON() ([]byte, error) {
+	w := jsonryInPointer.Writer()
+	w.Key("T")
+	w.Value(0, &x.T)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the InPointer from JSON in the same way as jsonry.Unmarshal()
+func (x *InPointer) UnmarshalJSON(data []byte) error {
+	r, err := jsonryInPointer.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.T)
+	return r.Err()
+}
+
+var jsonryInSlice = gen.NewPlan(
+	gen.Field{Name: "T"},
+)
+
+// MarshalJSON converts the InSlice into JSON in the same way as jsonry.Marshal()
+func (x InSlice) MarshalJSON() ([]byte, error) {
+	w := jsonryInSlice.Writer()
+	w.Key("T")
+	w.Value(0, &x.T)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the InSlice from JSON in the same way as jsonry.Unmarshal()
+func (x *InSlice) UnmarshalJSON(data []byte) error {
+	r, err := jsonryInSlice.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.T)
+	return r.Err()
+}
+
+var jsonryInMap = gen.NewPlan(
+	gen.Field{Name: "T"},
+)
+
+// MarshalJSON converts the InMap into JSON in the same way as jsonry.Marshal()
+func (x InMap) MarshalJSON() ([]byte, error) {
+	w := jsonryInMap.Writer()
+	w.Key("T")
+	w.Value(0, &x.T)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the InMap from JSON in the same way as jsonry.Unmarshal()
+func (x *InMap) UnmarshalJSON(data []byte) error {
+	r, err := jsonryInMap.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.T)
+	return r.Err()
+}
+
+var jsonryOmitEmptyTags = gen.NewPlan(
+	gen.Field{Name: "A", Tag: `json:",omitempty"`},
+	gen.Field{Name: "B", Tag: `json:"bee,omitempty"`},
+	gen.Field{Name: "C", Tag: `jsonry:",omitempty"`},
+	gen.Field{Name: "D", Tag: `jsonry:"dee,omitempty"`},
+)
+
+// MarshalJSON converts the OmitEmptyTags into JSON in the same way as jsonry.Marshal()
+func (x OmitEmptyTags) MarshalJSON() ([]byte, error) {
+	w := jsonryOmitEmptyTags.Writer()
+	if x.A != "" {
+		w.Key("A")
+		w.String(x.A)
+	}
+	if x.C != "" {
+		w.Key("C")
+		w.String(x.C)
+	}
+	if x.B != "" {
+		w.Key("bee")
+		w.String(x.B)
+	}
+	if x.D != "" {
+		w.Key("dee")
+		w.String(x.D)
+	}
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the OmitEmptyTags from JSON in the same way as jsonry.Unmarshal()
+func (x *OmitEmptyTags) UnmarshalJSON(data []byte) error {
+	r, err := jsonryOmitEmptyTags.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.String(r, 0, &x.A)
+	gen.String(r, 1, &x.B)
+	gen.String(r, 2, &x.C)
+	gen.String(r, 3, &x.D)
+	return r.Err()
+}
+
+var jsonryOmitFalse = gen.NewPlan(
+	gen.Field{Name: "A", Tag: `jsonry:",omitempty"`},
+)
+
+// MarshalJSON converts the OmitFalse into JSON in the same way as jsonry.Marshal()
+func (x OmitFalse) MarshalJSON() ([]byte, error) {
+	w := jsonryOmitFalse.Writer()
+	if x.A {
+		w.Key("A")
+		w.Bool(x.A)
+	}
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the OmitFalse from JSON in the same way as jsonry.Unmarshal()
+func (x *OmitFalse) UnmarshalJSON(data []byte) error {
+	r, err := jsonryOmitFalse.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.Bool(r, 0, &x.A)
+	return r.Err()
+}
+
+var jsonryOmitZero = gen.NewPlan(
+	gen.Field{Name: "A", Tag: `jsonry:",omitempty"`},
+	gen.Field{Name: "B", Tag: `jsonry:",omitempty"`},
+	gen.Field{Name: "C", Tag: `jsonry:",omitempty"`},
+)
+
+// MarshalJSON converts the OmitZero into JSON in the same way as jsonry.Marshal()
+func (x OmitZero) MarshalJSON() ([]byte, error) {
+	w := jsonryOmitZero.Writer()
+	if x.A != 0 {
+		w.Key("A")
+		w.Int(int64(x.A))
+	}
+	if x.B != 0 {
+		w.Key("B")
+		w.Uint(uint64(x.B))
+	}
+	if x.C != 0 {
+		w.Key("C")
+		w.Float64(x.C)
+	}
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the OmitZero from JSON in the same way as jsonry.Unmarshal()
+func (x *OmitZero) UnmarshalJSON(data []byte) error {
+	r, err := jsonryOmitZero.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.Int(r, 0, &x.A)
+	gen.Uint(r, 1, &x.B)
+	gen.Float(r, 2, &x.C)
+	return r.Err()
+}
+
+var jsonryOmitNilPointers = gen.NewPlan(
+	gen.Field{Name: "A", Tag: `jsonry:",omitempty"`},
+	gen.Field{Name: "B", Tag: `jsonry:",omitempty"`},
+	gen.Field{Name: "C", Tag: `jsonry:",omitempty"`},
+	gen.Field{Name: "D", Tag: `jsonry:",omitempty"`},
+	gen.Field{Name: "E", Tag: `jsonry:",omitempty"`},
+)
+
+// MarshalJSON converts the OmitNilPointers into JSON in the same way as jsonry.Marshal()
+func (x OmitNilPointers) MarshalJSON() ([]byte, error) {
+	w := jsonryOmitNilPointers.Writer()
+	if x.A != nil {
+		w.Key("A")
+		w.Value(0, &x.A)
+	}
+	if x.B != nil {
+		w.Key("B")
+		w.Value(1, &x.B)
+	}
+	if x.C != nil {
+		w.Key("C")
+		w.Value(2, &x.C)
+	}
+	if x.D != nil {
+		w.Key("D")
+		w.Value(3, &x.D)
+	}
+	if x.E != nil {
+		w.Key("E")
+		w.Value(4, &x.E)
+	}
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the OmitNilPointers from JSON in the same way as jsonry.Unmarshal()
+func (x *OmitNilPointers) UnmarshalJSON(data []byte) error {
+	r, err := jsonryOmitNilPointers.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.A)
+	r.Value(1, &x.B)
+	r.Value(2, &x.C)
+	r.Value(3, &x.D)
+	r.Value(4, &x.E)
+	return r.Err()
+}
+
+var jsonryOmitNilInterfaces = gen.NewPlan(
+	gen.Field{Name: "A", Tag: `jsonry:",omitempty"`},
+	gen.Field{Name: "B", Tag: `jsonry:",omitempty"`},
+)
+
+// MarshalJSON converts the OmitNilInterfaces into JSON in the same way as jsonry.Marshal()
+func (x OmitNilInterfaces) MarshalJSON() ([]byte, error) {
+	w := jsonryOmitNilInterfaces.Writer()
+	if x.A != nil {
+		w.Key("A")
+		w.Value(0, &x.A)
+	}
+	if x.B != nil {
+		w.Key("B")
+		w.Value(1, &x.B)
+	}
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the OmitNilInterfaces from JSON in the same way as jsonry.Unmarshal()
+func (x *OmitNilInterfaces) UnmarshalJSON(data []byte) error {
+	r, err := jsonryOmitNilInterfaces.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.A)
+	r.Value(1, &x.B)
+	return r.Err()
+}
+
+var jsonryOmitEmptyArray = gen.NewPlan(
+	gen.Field{Name: "A", Tag: `jsonry:",omitempty"`},
+)
+
+// MarshalJSON converts the OmitEmptyArray into JSON in the same way as jsonry.Marshal()
+func (x OmitEmptyArray) MarshalJSON() ([]byte, error) {
+	w := jsonryOmitEmptyArray.Writer()
+	if len(x.A) != 0 {
+		w.Key("A")
+		w.Value(0, &x.A)
+	}
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the OmitEmptyArray from JSON in the same way as jsonry.Unmarshal()
+func (x *OmitEmptyArray) UnmarshalJSON(data []byte) error {
+	r, err := jsonryOmitEmptyArray.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.A)
+	return r.Err()
+}
+
+var jsonryOmitEmptySlices = gen.NewPlan(
+	gen.Field{Name: "A", Tag: `jsonry:",omitempty"`},
+	gen.Field{Name: "B", Tag: `jsonry:",omitempty"`},
+	gen.Field{Name: "C", Tag: `jsonry:",omitempty"`},
+)
+
+// MarshalJSON converts the OmitEmptySlices into JSON in the same way as jsonry.Marshal()
+func (x OmitEmptySlices) MarshalJSON() ([]byte, error) {
+	w := jsonryOmitEmptySlices.Writer()
+	if len(x.A) != 0 {
+		w.Key("A")
+		w.Value(0, &x.A)
+	}
+	if len(x.B) != 0 {
+		w.Key("B")
+		w.Value(1, &x.B)
+	}
+	if len(x.C) != 0 {
+		w.Key("C")
+		w.Value(2, &x.C)
+	}
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the OmitEmptySlices from JSON in the same way as jsonry.Unmarshal()
+func (x *OmitEmptySlices) UnmarshalJSON(data []byte) error {
+	r, err := jsonryOmitEmptySlices.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.A)
+	r.Value(1, &x.B)
+	r.Value(2, &x.C)
+	return r.Err()
+}
+
+var jsonryOmitEmptyMaps = gen.NewPlan(
+	gen.Field{Name: "A", Tag: `jsonry:",omitempty"`},
+	gen.Field{Name: "D", Tag: `jsonry:",omitempty"`},
+)
+
+// MarshalJSON converts the OmitEmptyMaps into JSON in the same way as jsonry.Marshal()
+func (x OmitEmptyMaps) MarshalJSON() ([]byte, error) {
+	w := jsonryOmitEmptyMaps.Writer()
+	if len(x.A) != 0 {
+		w.Key("A")
+		w.Value(0, &x.A)
+	}
+	if len(x.D) != 0 {
+		w.Key("D")
+		w.Value(1, &x.D)
+	}
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the OmitEmptyMaps from JSON in the same way as jsonry.Unmarshal()
+func (x *OmitEmptyMaps) UnmarshalJSON(data []byte) error {
+	r, err := jsonryOmitEmptyMaps.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.A)
+	r.Value(1, &x.D)
+	return r.Err()
+}
+
+var jsonryOmitEmptyStrings = gen.NewPlan(
+	gen.Field{Name: "A", Tag: `jsonry:",omitempty"`},
+	gen.Field{Name: "B", Tag: `jsonry:",omitempty"`},
+)
+
+// MarshalJSON converts the OmitEmptyStrings into JSON in the same way as jsonry.Marshal()
+func (x OmitEmptyStrings) MarshalJSON() ([]byte, error) {
+	w := jsonryOmitEmptyStrings.Writer()
+	if x.A != "" {
+		w.Key("A")
+		w.String(x.A)
+	}
+	if x.B != "" {
+		w.Key("B")
+		w.String(x.B)
+	}
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the OmitEmptyStrings from JSON in the same way as jsonry.Unmarshal()
+func (x *OmitEmptyStrings) UnmarshalJSON(data []byte) error {
+	r, err := jsonryOmitEmptyStrings.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.String(r, 0, &x.A)
+	gen.String(r, 1, &x.B)
+	return r.Err()
+}
+
+var jsonryOmitEmptyStruct = gen.NewPlan(
+	gen.Field{Name: "B", Tag: `jsonry:",omitempty"`},
+)
+
+// MarshalJSON converts the OmitEmptyStruct into JSON in the same way as jsonry.Marshal()
+func (x OmitEmptyStruct) MarshalJSON() ([]byte, error) {
+	w := jsonryOmitEmptyStruct.Writer()
+	w.Key("B")
+	w.Value(0, &x.B)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the OmitEmptyStruct from JSON in the same way as jsonry.Unmarshal()
+func (x *OmitEmptyStruct) UnmarshalJSON(data []byte) error {
+	r, err := jsonryOmitEmptyStruct.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.B)
+	return r.Err()
+}
+
+var jsonryOmitAlways = gen.NewPlan(
+	gen.Field{Name: "A", Tag: `jsonry:"-"`},
+)
+
+// MarshalJSON converts the OmitAlways into JSON in the same way as jsonry.Marshal()
+func (x OmitAlways) MarshalJSON() ([]byte, error) {
+	w := jsonryOmitAlways.Writer()
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the OmitAlways from JSON in the same way as jsonry.Unmarshal()
+func (x *OmitAlways) UnmarshalJSON(data []byte) error {
+	r, err := jsonryOmitAlways.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.String(r, 0, &x.A)
+	return r.Err()
+}
+
+var jsonryDash = gen.NewPlan(
+	gen.Field{Name: "A", Tag: `jsonry:"-,"`},
+)
+
+// MarshalJSON converts the Dash into JSON in the same way as jsonry.Marshal()
+func (x Dash) MarshalJSON() ([]byte, error) {
+	w := jsonryDash.Writer()
+	w.Key("-")
+	w.String(x.A)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Dash from JSON in the same way as jsonry.Unmarshal()
+func (x *Dash) UnmarshalJSON(data []byte) error {
+	r, err := jsonryDash.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.String(r, 0, &x.A)
+	return r.Err()
+}
+
+var jsonryOmissible = gen.NewPlan(
+	gen.Field{Name: "A"},
+)
+
+// MarshalJSON converts the Omissible into JSON in the same way as jsonry.Marshal()
+func (x Omissible) MarshalJSON() ([]byte, error) {
+	w := jsonryOmissible.Writer()
+	if !x.A.OmitJSONry() {
+		w.Key("A")
+		w.Value(0, &x.A)
+	}
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Omissible from JSON in the same way as jsonry.Unmarshal()
+func (x *Omissible) UnmarshalJSON(data []byte) error {
+	r, err := jsonryOmissible.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.A)
+	return r.Err()
+}
+
+var jsonryOmissibleOverride = gen.NewPlan(
+	gen.Field{Name: "A", Tag: `jsonry:",omitempty"`},
+	gen.Field{Name: "B", Tag: `jsonry:",omitempty"`},
+)
+
+// MarshalJSON converts the OmissibleOverride into JSON in the same way as jsonry.Marshal()
+func (x OmissibleOverride) MarshalJSON() ([]byte, error) {
+	w := jsonryOmissibleOverride.Writer()
+	if !x.A.OmitJSONry() {
+		w.Key("A")
+		w.Value(0, &x.A)
+	}
+	if x.B != "" {
+		w.Key("B")
+		w.String(x.B)
+	}
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the OmissibleOverride from JSON in the same way as jsonry.Unmarshal()
+func (x *OmissibleOverride) UnmarshalJSON(data []byte) error {
+	r, err := jsonryOmissibleOverride.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.A)
+	gen.String(r, 1, &x.B)
+	return r.Err()
+}
+
+var jsonryOmitsNilPointers = gen.NewPlan(
+	gen.Field{Name: "A"},
+	gen.Field{Name: "B"},
+)
+
+// MarshalJSON converts the OmitsNilPointers into JSON in the same way as jsonry.Marshal()
+func (x OmitsNilPointers) MarshalJSON() ([]byte, error) {
+	w := jsonryOmitsNilPointers.Writer()
+	if !x.A.OmitJSONry() {
+		w.Key("A")
+		w.Value(0, &x.A)
+	}
+	if !x.B.OmitJSONry() {
+		w.Key("B")
+		w.Value(1, &x.B)
+	}
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the OmitsNilPointers from JSON in the same way as jsonry.Unmarshal()
+func (x *OmitsNilPointers) UnmarshalJSON(data []byte) error {
+	r, err := jsonryOmitsNilPointers.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.A)
+	r.Value(1, &x.B)
+	return r.Err()
+}
+
+var jsonryOmissiblePointers = gen.NewPlan(
+	gen.Field{Name: "A"},
+	gen.Field{Name: "B", Tag: `jsonry:",omitempty"`},
+)
+
+// MarshalJSON converts the OmissiblePointers into JSON in the same way as jsonry.Marshal()
+func (x OmissiblePointers) MarshalJSON() ([]byte, error) {
+	w := jsonryOmissiblePointers.Writer()
+	if x.A == nil || !x.A.OmitJSONry() {
+		w.Key("A")
+		w.Value(0, &x.A)
+	}
+	if x.B != nil && !x.B.OmitJSONry() {
+		w.Key("B")
+		w.Value(1, &x.B)
+	}
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the OmissiblePointers from JSON in the same way as jsonry.Unmarshal()
+func (x *OmissiblePointers) UnmarshalJSON(data []byte) error {
+	r, err := jsonryOmissiblePointers.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.A)
+	r.Value(1, &x.B)
+	return r.Err()
+}
+
+var jsonrySharedPrefix = gen.NewPlan(
+	gen.Field{Name: "A", Tag: `jsonry:"a.b.c"`},
+	gen.Field{Name: "B", Tag: `jsonry:"a.b.d"`},
+	gen.Field{Name: "C", Tag: `jsonry:"a.e"`},
+	gen.Field{Name: "D", Tag: `jsonry:"a.f.g"`},
+	gen.Field{Name: "E", Tag: `jsonry:"h"`},
+	gen.Field{Name: "F", Tag: `jsonry:"a.f.i"`},
+)
+
+// MarshalJSON converts the SharedPrefix into JSON in the same way as jsonry.Marshal()
+func (x SharedPrefix) MarshalJSON() ([]byte, error) {
+	w := jsonrySharedPrefix.Writer()
+	w.Object("a")
+	w.Object("b")
+	w.Key("c")
+	w.String(x.A)
+	w.Key("d")
+	w.String(x.B)
+	w.End()
+	w.Key("e")
+	w.String(x.C)
+	w.Object("f")
+	w.Key("g")
+	w.Value(3, &x.D)
+	w.Key("i")
+	w.Value(5, &x.F)
+	w.End()
+	w.End()
+	w.Key("h")
+	w.String(x.E)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the SharedPrefix from JSON in the same way as jsonry.Unmarshal()
+func (x *SharedPrefix) UnmarshalJSON(data []byte) error {
+	r, err := jsonrySharedPrefix.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.String(r, 0, &x.A)
+	gen.String(r, 1, &x.B)
+	gen.String(r, 2, &x.C)
+	r.Value(3, &x.D)
+	gen.String(r, 4, &x.E)
+	r.Value(5, &x.F)
+	return r.Err()
+}
+
+var jsonryPrefixField = gen.NewPlan(
+	gen.Field{Name: "A", Tag: `jsonry:"a"`},
+	gen.Field{Name: "B", Tag: `jsonry:"a.b"`},
+)
+
+// MarshalJSON converts the PrefixField into JSON using jsonry.Marshal(), because the path of field "B" conflicts with another field
+func (x PrefixField) MarshalJSON() ([]byte, error) {
+	return jsonry.Marshal(x)
+}
+
+// UnmarshalJSON sets the PrefixField from JSON in the same way as jsonry.Unmarshal()
+func (x *PrefixField) UnmarshalJSON(data []byte) error {
+	r, err := jsonryPrefixField.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.A)
+	gen.String(r, 1, &x.B)
+	return r.Err()
+}
+
+var jsonryRepeated = gen.NewPlan(
+	gen.Field{Name: "A", Tag: `jsonry:"a.b"`},
+	gen.Field{Name: "C", Tag: `jsonry:"c"`},
+)
+
+// MarshalJSON converts the Repeated into JSON in the same way as jsonry.Marshal()
+func (x Repeated) MarshalJSON() ([]byte, error) {
+	w := jsonryRepeated.Writer()
+	w.Object("a")
+	w.Key("b")
+	w.String(x.A)
+	w.End()
+	w.Key("c")
+	w.String(x.C)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Repeated from JSON in the same way as jsonry.Unmarshal()
+func (x *Repeated) UnmarshalJSON(data []byte) error {
+	r, err := jsonryRepeated.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.String(r, 0, &x.A)
+	gen.String(r, 1, &x.C)
+	return r.Err()
+}
+
+var jsonryEscapes = gen.NewPlan(
+	gen.Field{Name: "A", Tag: `json:"é"`},
+)
+
+// MarshalJSON converts the Escapes into JSON in the same way as jsonry.Marshal()
+func (x Escapes) MarshalJSON() ([]byte, error) {
+	w := jsonryEscapes.Writer()
+	w.Key("é")
+	w.String(x.A)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the Escapes from JSON in the same way as jsonry.Unmarshal()
+func (x *Escapes) UnmarshalJSON(data []byte) error {
+	r, err := jsonryEscapes.Read(data)
+	if err != nil {
+		return err
+	}
+
+	gen.String(r, 0, &x.A)
+	return r.Err()
+}
+
+// MarshalJSON converts the Remain into JSON using jsonry.Marshal(), because it has a ",remain" field
+func (x Remain) MarshalJSON() ([]byte, error) {
+	return jsonry.Marshal(x)
+}
+
+// UnmarshalJSON sets the Remain from JSON using jsonry.Unmarshal(), because it has a ",remain" field
+func (x *Remain) UnmarshalJSON(data []byte) error {
+	return jsonry.Unmarshal(data, x)
+}
+
+// MarshalJSON converts the RemainMerge into JSON using jsonry.Marshal(), because it has a ",remain" field
+func (x RemainMerge) MarshalJSON() ([]byte, error) {
+	return jsonry.Marshal(x)
+}
+
+// UnmarshalJSON sets the RemainMerge from JSON using jsonry.Unmarshal(), because it has a ",remain" field
+func (x *RemainMerge) UnmarshalJSON(data []byte) error {
+	return jsonry.Unmarshal(data, x)
+}
+
+// MarshalJSON converts the RemainNotMap into JSON using jsonry.Marshal(), because it has a ",remain" field
+func (x RemainNotMap) MarshalJSON() ([]byte, error) {
+	return jsonry.Marshal(x)
+}
+
+// UnmarshalJSON sets the RemainNotMap from JSON using jsonry.Unmarshal(), because it has a ",remain" field
+func (x *RemainNotMap) UnmarshalJSON(data []byte) error {
+	return jsonry.Unmarshal(data, x)
+}
+
+// MarshalJSON converts the RemainRaw into JSON using jsonry.Marshal(), because it has a ",remain" field
+func (x RemainRaw) MarshalJSON() ([]byte, error) {
+	return jsonry.Marshal(x)
+}
+
+// UnmarshalJSON sets the RemainRaw from JSON using jsonry.Unmarshal(), because it has a ",remain" field
+func (x *RemainRaw) UnmarshalJSON(data []byte) error {
+	return jsonry.Unmarshal(data, x)
+}
+
+var jsonryRemainNested = gen.NewPlan(
+	gen.Field{Name: "I", Tag: `jsonry:"i"`},
+)
+
+// MarshalJSON converts the RemainNested into JSON in the same way as jsonry.Marshal()
+func (x RemainNested) MarshalJSON() ([]byte, error) {
+	w := jsonryRemainNested.Writer()
+	w.Key("i")
+	w.Value(0, &x.I)
+	return w.Bytes()
+}
+
+// UnmarshalJSON sets the RemainNested from JSON in the same way as jsonry.Unmarshal()
+func (x *RemainNested) UnmarshalJSON(data []byte) error {
+	r, err := jsonryRemainNested.Read(data)
+	if err != nil {
+		return err
+	}
+
+	r.Value(0, &x.I)
+	return r.Err()
+}
@@ -0,0 +1,272 @@
+package tables_test
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"code.cloudfoundry.org/jsonry"
+	"code.cloudfoundry.org/jsonry/cmd/jsonrygen/internal/tables"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UnmarshalJSON", func() {
+	// copy makes a new receiver with the same initial value, so that it can be passed to jsonry.Unmarshal()
+	copy := func(receiver json.Unmarshaler) interface{} {
+		v := reflect.New(reflect.TypeOf(receiver).Elem())
+		v.Elem().Set(reflect.ValueOf(receiver).Elem())
+		return v.Interface()
+	}
+
+	str := func(s string) *string { return &s }
+	num := func(i int) *int { return &i }
+	raw := func(s string) *json.RawMessage { r := json.RawMessage(s); return &r }
+
+	DescribeTable("produces the same result as the tests for jsonry.Unmarshal()",
+		func(data string, receiver json.Unmarshaler, expected interface{}) {
+			reflected := copy(receiver)
+			Expect(receiver.UnmarshalJSON([]byte(data))).To(Succeed())
+			Expect(receiver).To(Equal(expected))
+
+			Expect(jsonry.Unmarshal([]byte(data), reflected)).To(Succeed())
+			Expect(receiver).To(Equal(reflected))
+		},
+		Entry("a basic string field", `{"Foo": "works"}`, new(tables.Basic), &tables.Basic{Foo: "works"}),
+		Entry("the path defaulting to the field name", `{"GUID":"123"}`, new(tables.FieldName), &tables.FieldName{GUID: "123"}),
+		Entry("a JSON tag", `{"guid":"123"}`, new(tables.JSONTag), &tables.JSONTag{GUID: "123"}),
+		Entry("a JSONry tag", `{"relationships":{"spaces":{"guid":"123"}}}`, new(tables.NestedPath), &tables.NestedPath{GUID: "123"}),
+		Entry("a string", `{"S":"hello"}`, new(tables.String), &tables.String{S: "hello"}),
+		Entry("a bool", `{"T":true,"F":false}`, &tables.Bool{F: true}, &tables.Bool{T: true}),
+		Entry("an int", `{"I":-42}`, new(tables.Int), &tables.Int{I: -42}),
+		Entry("an int8", `{"I":-42}`, new(tables.Int8), &tables.Int8{I: -42}),
+		Entry("an int16", `{"I":-42}`, new(tables.Int16), &tables.Int16{I: -42}),
+		Entry("an int32", `{"I":-42}`, new(tables.Int32), &tables.Int32{I: -42}),
+		Entry("an int64", `{"I":-42}`, new(tables.Int64), &tables.Int64{I: -42}),
+		Entry("a uint", `{"I":42}`, new(tables.Uint), &tables.Uint{I: 42}),
+		Entry("a uint8", `{"I":42}`, new(tables.Uint8), &tables.Uint8{I: 42}),
+		Entry("a uint16", `{"I":42}`, new(tables.Uint16), &tables.Uint16{I: 42}),
+		Entry("a uint32", `{"I":42}`, new(tables.Uint32), &tables.Uint32{I: 42}),
+		Entry("a uint64", `{"I":42}`, new(tables.Uint64), &tables.Uint64{I: 42}),
+		Entry("a float32", `{"E":4.2e15,"F":4.2}`, new(tables.Float32), &tables.Float32{E: 4.2e15, F: 4.2}),
+		Entry("a float64", `{"E":4.2e-15,"F":4.2}`, new(tables.Float64), &tables.Float64{E: 4.2e-15, F: 4.2}),
+		Entry("sized ints at their limits",
+			`{"I8":127,"I16":-32768,"I32":2147483647,"I64":-9223372036854775808}`,
+			new(tables.SizedInts),
+			&tables.SizedInts{I8: 127, I16: -32768, I32: 2147483647, I64: -9223372036854775808},
+		),
+		Entry("sized uints at their limits", `{"U8":255,"U64":18446744073709551615}`, new(tables.SizedUints), &tables.SizedUints{U8: 255, U64: 18446744073709551615}),
+		Entry("floats at their limits", `{"F32":3.4e38,"F64":1.7e308}`, new(tables.Floats), &tables.Floats{F32: 3.4e38, F64: 1.7e308}),
+		Entry("interface{} fields",
+			`{"N":null,"B":true,"S":"foo","I":-42,"U":12,"F":4.2,"L":[1,2],"M":{"f":"b"}}`,
+			new(tables.Interfaces),
+			&tables.Interfaces{
+				B: true,
+				S: "foo",
+				I: -42,
+				U: 12,
+				F: 4.2,
+				L: []interface{}{json.Number("1"), json.Number("2")},
+				M: map[string]interface{}{"f": "b"},
+			},
+		),
+		Entry("pointers of basic types", `{"S":"foo","T":null,"I":12,"J":null}`, new(tables.BasicPointers), &tables.BasicPointers{S: str("foo"), I: num(12)}),
+		Entry("a slice of interface{}", `{"S": ["a",2,true]}`, new(tables.Slice), &tables.Slice{S: []interface{}{"a", 2, true}}),
+		Entry("a pointer to a slice of interface{}", `{"S": ["a",2,true]}`, new(tables.SlicePointer), &tables.SlicePointer{S: &[]interface{}{"a", 2, true}}),
+		Entry("a slice of string", `{"S":["a","b","c"]}`, new(tables.Strings), &tables.Strings{S: []string{"a", "b", "c"}}),
+		Entry("a pointer to a slice of string", `{"S":["a","b","c"]}`, new(tables.StringsPointer), &tables.StringsPointer{S: &[]string{"a", "b", "c"}}),
+		Entry("a slice of int", `{"N":[1,2,3]}`, new(tables.Ints), &tables.Ints{N: []int{1, 2, 3}}),
+		Entry("a pointer to a slice of int", `{"N":[1,2,3]}`, new(tables.IntsPointer), &tables.IntsPointer{N: &[]int{1, 2, 3}}),
+		Entry("an omitted slice", `{}`, new(tables.Slice), &tables.Slice{}),
+		Entry("an omitted pointer to a slice", `{}`, new(tables.SlicePointer), &tables.SlicePointer{}),
+		Entry("a null slice", `{"S": null}`, new(tables.Slice), &tables.Slice{}),
+		Entry("a null pointer to a slice", `{"S": null}`, new(tables.SlicePointer), &tables.SlicePointer{}),
+		Entry("an empty slice", `{"S": []}`, new(tables.Slice), &tables.Slice{S: []interface{}{}}),
+		Entry("a pointer to an empty slice", `{"S": []}`, new(tables.SlicePointer), &tables.SlicePointer{S: &[]interface{}{}}),
+		Entry("a []byte from base64", `{"B":"aGVsbG//"}`, new(tables.Bytes), &tables.Bytes{B: []byte("hello\xff")}),
+		Entry("a pointer to a []byte from base64", `{"B":"aGk="}`, new(tables.BytesPointer), &tables.BytesPointer{B: &[]byte{'h', 'i'}}),
+		Entry("a []byte from an array of numbers", `{"B":[104,105]}`, new(tables.Bytes), &tables.Bytes{B: []byte("hi")}),
+		Entry("a []byte from URL-safe base64", `{"b":"aGVsbG__","c":"aGk"}`, new(tables.Base64URL), &tables.Base64URL{B: []byte("hello\xff"), C: []byte("hi")}),
+		Entry("a []byte from hex",
+			`{"a":{"b":"deadbeef"},"L":["01","0203"],"M":{"a":"ff"}}`,
+			new(tables.HexLists),
+			&tables.HexLists{B: []byte{0xde, 0xad, 0xbe, 0xef}, L: [][]byte{{1}, {2, 3}}, M: map[string][]byte{"a": {0xff}}},
+		),
+		Entry("a named byte slice type", `{"B":"aGk="}`, new(tables.NamedBytes), &tables.NamedBytes{B: tables.ByteSlice("hi")}),
+		Entry("a map with interface values", `{"M":{"a":"b","c":5,"d":true}}`, new(tables.Map), &tables.Map{M: map[string]interface{}{"a": "b", "c": 5, "d": true}}),
+		Entry("a pointer to a map with interface values",
+			`{"M":{"a":"b","c":5,"d":true}}`,
+			new(tables.MapPointer),
+			&tables.MapPointer{M: &map[string]interface{}{"a": "b", "c": 5, "d": true}},
+		),
+		Entry("a map with string values", `{"M":{"a":"b","c":"d"}}`, new(tables.MapString), &tables.MapString{M: map[string]string{"a": "b", "c": "d"}}),
+		Entry("a pointer to a map with string values", `{"M":{"a":"b","c":"d"}}`, new(tables.MapStringPointer), &tables.MapStringPointer{M: &map[string]string{"a": "b", "c": "d"}}),
+		Entry("a map with number values", `{"N":{"f":5}}`, new(tables.MapInt), &tables.MapInt{N: map[string]int{"f": 5}}),
+		Entry("a pointer to a map with number values", `{"N":{"f":5}}`, new(tables.MapIntPointer), &tables.MapIntPointer{N: &map[string]int{"f": 5}}),
+		Entry("an omitted map", `{}`, new(tables.Map), &tables.Map{}),
+		Entry("an omitted pointer to a map", `{}`, new(tables.MapPointer), &tables.MapPointer{}),
+		Entry("a null map", `{"M": null}`, new(tables.Map), &tables.Map{}),
+		Entry("a null pointer to a map", `{"M": null}`, new(tables.MapPointer), &tables.MapPointer{}),
+		Entry("an empty map", `{"M": {}}`, new(tables.Map), &tables.Map{M: map[string]interface{}{}}),
+		Entry("a pointer to an empty map", `{"M": {}}`, new(tables.MapPointer), &tables.MapPointer{M: &map[string]interface{}{}}),
+		Entry("a map with keys that are string type definitions",
+			`{"M": { "data": "some-data" } }`,
+			new(tables.MapStringy),
+			&tables.MapStringy{M: map[tables.Stringy]string{"data": "some-data"}},
+		),
+		Entry("a json.Unmarshaler", `{"S":"ok"}`, new(tables.JSONUnmarshaler), &tables.JSONUnmarshaler{S: tables.ImplementsJSONUnmarshaler{HasBeenSet: true}}),
+		Entry("the input to a json.Unmarshaler exactly as it appears",
+			`{"a": {"b": { "z": 1, "a": 12345678901234567890 }, "c": [ 1.50 , "é" ]}}`,
+			new(tables.RecordsJSONs),
+			&tables.RecordsJSONs{
+				R: tables.RecordsJSON{Input: []byte(`{ "z": 1, "a": 12345678901234567890 }`)},
+				L: []tables.RecordsJSON{{Input: []byte(`1.50`)}, {Input: []byte(`"é"`)}},
+			},
+		),
+		Entry("a json.RawMessage exactly as it appears in the input",
+			`{"a": {"b": {"z": 1,  "a":[1.50e1, "é"]}, "c": 42.0, "d": "x"}, "N": null, "Q": null}`,
+			&tables.RawMessages{Q: new(json.RawMessage)},
+			&tables.RawMessages{
+				R: json.RawMessage(`{"z": 1,  "a":[1.50e1, "é"]}`),
+				P: raw(`42.0`),
+				S: json.RawMessage(`"x"`),
+				N: json.RawMessage(`null`),
+			},
+		),
+		Entry("lists of json.RawMessage",
+			`{"items": [{"data": {"b":2, "a":1}}, {}, {"data": [ 1 ]}]}`,
+			new(tables.RawMessageLists),
+			&tables.RawMessageLists{
+				L: []json.RawMessage{json.RawMessage(`{"data": {"b":2, "a":1}}`), json.RawMessage(`{}`), json.RawMessage(`{"data": [ 1 ]}`)},
+				M: json.RawMessage(`[{"b":2, "a":1},null,[ 1 ]]`),
+			},
+		),
+		Entry("named types and type aliases", `{"A":"foo","N":"bar"}`, new(tables.Named), &tables.Named{A: "foo", N: "bar"}),
+		Entry("null leaving basic types untouched",
+			`{"S": null, "T": null, "U": null, "V": null, "W": null}`,
+			&tables.Nulls{S: "foo", T: -65, U: 12, V: 3.14, W: true},
+			&tables.Nulls{S: "foo", T: -65, U: 12, V: 3.14, W: true},
+		),
+		Entry("null leaving struct types untouched", `{"A": null}`, &tables.NullStruct{A: tables.Inner{S: "hello"}}, &tables.NullStruct{A: tables.Inner{S: "hello"}}),
+		Entry("null overwriting a pointer as nil", `{"S": null}`, &tables.NullPointer{S: str("hello")}, &tables.NullPointer{}),
+		Entry("null overwriting an interface{} as nil", `{"S": null}`, &tables.NullInterface{S: "hello"}, &tables.NullInterface{}),
+		Entry("fields which share a path prefix",
+			`{"x":{"y":[1,{"z":2}]},"a":{"b":{"d":"bar","c":"foo"},"f":[{"g":"g1","i":1},{"g":"g2"}],"e":"baz"},"h":"quz"}`,
+			new(tables.SharedPrefix),
+			&tables.SharedPrefix{A: "foo", B: "bar", C: "baz", D: []string{"g1", "g2"}, E: "quz", F: []int{1, 0}},
+		),
+		Entry("a field which is also a path prefix of another field",
+			`{"a":{"b":"foo"}}`,
+			new(tables.PrefixField),
+			&tables.PrefixField{A: map[string]interface{}{"b": "foo"}, B: "foo"},
+		),
+		Entry("the last value when a key is repeated",
+			`{"a":{"b":"first"},"c":"first","a":{"b":"second"},"c":"second"}`,
+			new(tables.Repeated),
+			&tables.Repeated{A: "second", C: "second"},
+		),
+		Entry("keys containing escapes", `{"é":"foo"}`, new(tables.Escapes), &tables.Escapes{A: "foo"}),
+		Entry("a struct field", `{"T":{"S":"foo"}}`, new(tables.InStruct), &tables.InStruct{T: tables.Inner{S: "foo"}}),
+		Entry("a struct pointer field", `{"T":{"S":"foo"}}`, new(tables.InPointer), &tables.InPointer{T: &tables.Inner{S: "foo"}}),
+		Entry("a slice of structs",
+			`{"T":[{"S":"foo"},{"S":"bar"},{},{"S":"baz"}]}`,
+			new(tables.InSlice),
+			&tables.InSlice{T: []tables.Inner{{S: "foo"}, {S: "bar"}, {}, {S: "baz"}}},
+		),
+		Entry("a map of structs",
+			`{"T":{"foo":{"S":"alpha"},"bar":{"S":"beta"}}}`,
+			new(tables.InMap),
+			&tables.InMap{T: map[string]tables.Inner{"foo": {S: "alpha"}, "bar": {S: "beta"}}},
+		),
+		Entry("a remainder with the keys that no other field uses",
+			`{"name":"foo","metadata":{"guid":"bar","labels":{}},"state":"STARTED","links":{"self":"baz"}}`,
+			new(tables.Remain),
+			&tables.Remain{
+				Name: "foo",
+				GUID: "bar",
+				Other: map[string]interface{}{
+					"state":    "STARTED",
+					"links":    map[string]interface{}{"self": "baz"},
+					"metadata": map[string]interface{}{"labels": map[string]interface{}{}},
+				},
+			},
+		),
+		Entry("a remainder with unknown keys in nested objects",
+			`{"lifecycle":{"type":"buildpack","unknown":true,"data":{"stacks":["s"],"more":1}},"apps":[{"name":"a","guid":"g"}]}`,
+			new(tables.RemainRaw),
+			&tables.RemainRaw{
+				Type:   "buildpack",
+				Stacks: []string{"s"},
+				Names:  []string{"a"},
+				Other:  map[string]json.RawMessage{"lifecycle": json.RawMessage(`{"data":{"more":1},"unknown":true}`)},
+			},
+		),
+		Entry("a remainder left as nil when there are no other keys", `{"name":"foo"}`, new(tables.Remain), &tables.Remain{Name: "foo"}),
+		Entry("a remainder in a nested struct",
+			`{"i":{"A":1,"B":2,"C":3}}`,
+			new(tables.RemainNested),
+			&tables.RemainNested{I: tables.InnerRemain{A: 1, Other: map[string]int{"B": 2, "C": 3}}},
+		),
+	)
+
+	DescribeTable("returns the same errors as the tests for jsonry.Unmarshal()",
+		func(data string, receiver json.Unmarshaler, message string) {
+			reflected := copy(receiver)
+			Expect(receiver.UnmarshalJSON([]byte(data))).To(MatchError(message))
+			Expect(jsonry.Unmarshal([]byte(data), reflected)).To(MatchError(message))
+		},
+		Entry("a string", `{"S": 12}`, new(tables.String), `cannot unmarshal "12" type "number" into field "S" (type "string")`),
+		Entry("a bool", `{"T": 12}`, new(tables.Bool), `cannot unmarshal "12" type "number" into field "T" (type "bool")`),
+		Entry("an int", `{"I":"foo"}`, new(tables.Int), `cannot unmarshal "foo" type "string" into field "I" (type "int")`),
+		Entry("an int8", `{"I":"foo"}`, new(tables.Int8), `cannot unmarshal "foo" type "string" into field "I" (type "int8")`),
+		Entry("an int16", `{"I":"foo"}`, new(tables.Int16), `cannot unmarshal "foo" type "string" into field "I" (type "int16")`),
+		Entry("an int32", `{"I":"foo"}`, new(tables.Int32), `cannot unmarshal "foo" type "string" into field "I" (type "int32")`),
+		Entry("an int64", `{"I":"foo"}`, new(tables.Int64), `cannot unmarshal "foo" type "string" into field "I" (type "int64")`),
+		Entry("a uint", `{"I":"foo"}`, new(tables.Uint), `cannot unmarshal "foo" type "string" into field "I" (type "uint")`),
+		Entry("a uint8", `{"I":"foo"}`, new(tables.Uint8), `cannot unmarshal "foo" type "string" into field "I" (type "uint8")`),
+		Entry("a uint16", `{"I":"foo"}`, new(tables.Uint16), `cannot unmarshal "foo" type "string" into field "I" (type "uint16")`),
+		Entry("a uint32", `{"I":"foo"}`, new(tables.Uint32), `cannot unmarshal "foo" type "string" into field "I" (type "uint32")`),
+		Entry("a uint64", `{"I":"foo"}`, new(tables.Uint64), `cannot unmarshal "foo" type "string" into field "I" (type "uint64")`),
+		Entry("a float32", `{"E":"foo"}`, new(tables.Float32), `cannot unmarshal "foo" type "string" into field "E" (type "float32")`),
+		Entry("a float64", `{"E":"foo"}`, new(tables.Float64), `cannot unmarshal "foo" type "string" into field "E" (type "float64")`),
+		Entry("an int8 that overflows", `{"I8":300}`, new(tables.SizedInts), `number "300" is out of range for field "I8" (type "int8")`),
+		Entry("an int16 that overflows", `{"I16":-32769}`, new(tables.SizedInts), `number "-32769" is out of range for field "I16" (type "int16")`),
+		Entry("an int32 that overflows", `{"I32":2147483648}`, new(tables.SizedInts), `number "2147483648" is out of range for field "I32" (type "int32")`),
+		Entry("an int64 that overflows", `{"I64":9223372036854775808}`, new(tables.SizedInts), `number "9223372036854775808" is out of range for field "I64" (type "int64")`),
+		Entry("a uint8 that overflows", `{"U8":256}`, new(tables.SizedUints), `number "256" is out of range for field "U8" (type "uint8")`),
+		Entry("a negative uint8", `{"U8":-1}`, new(tables.SizedUints), `number "-1" is out of range for field "U8" (type "uint8")`),
+		Entry("a uint64 that overflows", `{"U64":18446744073709551616}`, new(tables.SizedUints), `number "18446744073709551616" is out of range for field "U64" (type "uint64")`),
+		Entry("a negative uint64", `{"U64":-99999999999999999999}`, new(tables.SizedUints), `number "-99999999999999999999" is out of range for field "U64" (type "uint64")`),
+		Entry("a float32 that overflows", `{"F32":3.5e38}`, new(tables.Floats), `number "3.5e38" is out of range for field "F32" (type "float32")`),
+		Entry("a float64 that overflows", `{"F64":1.8e308}`, new(tables.Floats), `number "1.8e308" is out of range for field "F64" (type "float64")`),
+		Entry("the path to a number that overflows",
+			`{"a":{"b":[{"I":1},{"I":1000}]}}`,
+			new(tables.NestedSmall),
+			`number "1000" is out of range for field "I" (type "int8") path L[1].I`,
+		),
+		Entry("a pointer to a sized type that overflows", `{"P":128}`, new(tables.PointerInt8), `number "128" is out of range for field "P" (type "*int8")`),
+		Entry("a float for an int", `{"I":3.0}`, new(tables.IntUint), `cannot unmarshal "3.0" type "number" into field "I" (type "int")`),
+		Entry("a float for a uint", `{"U":4e2}`, new(tables.IntUint), `cannot unmarshal "4e2" type "number" into field "U" (type "uint")`),
+		Entry("a complex64", `{}`, new(tables.Complex64), `unsupported type "complex64" at field "C" (type "complex64")`),
+		Entry("a complex128", `{}`, new(tables.Complex128), `unsupported type "complex128" at field "C" (type "complex128")`),
+		Entry("a pointer of a basic type", `{"J":"foo"}`, new(tables.BasicPointers), `cannot unmarshal "foo" type "string" into field "J" (type "*int")`),
+		Entry("an array", `{}`, new(tables.StringArray), `unsupported type "[3]string" at field "S" (type "[3]string")`),
+		Entry("invalid base64", `{"B":"not base64!"}`, new(tables.Bytes), `cannot unmarshal "not base64!" type "string" into field "B" (type "[]uint8")`),
+		Entry("invalid hex", `{"L":["0x"]}`, new(tables.HexLists), `cannot unmarshal "0x" type "string" into index 0 (type "[]uint8") path L[0]`),
+		Entry("a map that does not have string keys", `{}`, new(tables.MapIntKeys), `maps must only have string keys for "int" at field "M" (type "map[int]interface {}")`),
+		Entry("an error from a json.Unmarshaler",
+			`{"S":"fail"}`,
+			new(tables.JSONUnmarshaler),
+			`error from UnmarshalJSON() call at field "S" (type "tables.ImplementsJSONUnmarshaler"): ouch`,
+		),
+		Entry("a named type", `{"N":13}`, new(tables.Named), `cannot unmarshal "13" type "number" into field "N" (type "tables.NamedString")`),
+		Entry("a struct", `{"T":"foo"}`, new(tables.InStruct), `cannot unmarshal "foo" type "string" into field "T" (type "tables.Inner")`),
+		Entry("a struct pointer", `{"T":"foo"}`, new(tables.InPointer), `cannot unmarshal "foo" type "string" into field "T" (type "*tables.Inner")`),
+		Entry("a slice of structs", `{"T":[4]}`, new(tables.InSlice), `cannot unmarshal "4" type "number" into index 0 (type "tables.Inner") path T[0]`),
+		Entry("a map of structs", `{"T":5}`, new(tables.InMap), `cannot unmarshal "5" type "number" into field "T" (type "map[string]tables.Inner")`),
+		Entry("a remainder in a nested struct",
+			`{"i":{"B":"x"}}`,
+			new(tables.RemainNested),
+			`cannot unmarshal "x" type "string" into key "B" (type "int") path I.Other["B"]`,
+		),
+	)
+})
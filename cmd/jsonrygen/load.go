@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+)
+
+// load type checks the package in the directory. The output file is excluded, as it may
+// be out of date. Type errors are tolerated, since other files may use the methods that
+// are about to be generated.
+func load(dir, output string) (*types.Package, error) {
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range bp.GoFiles {
+		if name == output {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	var errs []error
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Error:    func(err error) { errs = append(errs, err) },
+	}
+
+	pkg, _ := conf.Check(bp.ImportPath, fset, files, nil)
+	if pkg == nil || len(files) == 0 {
+		return nil, fmt.Errorf("could not load package in %q: %v", dir, errs)
+	}
+
+	return pkg, nil
+}
@@ -0,0 +1,89 @@
+// Jsonrygen writes MarshalJSON() and UnmarshalJSON() methods for struct types with JSONry paths,
+// so that the scalar fields of the structs can be converted without reflection. For example, given
+// the file app.go:
+//
+//	package cf
+//
+//	//go:generate go run code.cloudfoundry.org/jsonry/cmd/jsonrygen -type=App
+//
+//	type App struct {
+//		GUID      string `jsonry:"guid"`
+//		SpaceGUID string `jsonry:"relationships.space.data.guid"`
+//	}
+//
+// Running "go generate" will write the methods to the file app_jsonry.go. The methods produce
+// the same JSON as jsonry.Marshal() and jsonry.Unmarshal() with the default Config, so the struct
+// can be used with encoding/json or any other library that calls the json.Marshaler and json.Unmarshaler
+// interfaces. The methods must be generated again when the struct changes.
+//
+// Only fields of type string, bool, int*, uint* and float* avoid reflection, because they are
+// converted by the generated code. All other fields, such as slices, maps, pointers, interfaces and
+// structs, and fields with a list hint in their path, are converted using reflection with the same
+// code as jsonry.Marshal() and jsonry.Unmarshal(). A struct type used by a field can have generated
+// methods too, which are then called through the json.Marshaler and json.Unmarshaler interfaces.
+// A jsonry.Context passed to a field of interface type has no Parent.
+//
+// Where the struct has a ",remain" field, a field that may implement jsonry.JSONryMarshaler or
+// jsonry.JSONryUnmarshaler, a field with a default value, a field whose type is a type parameter,
+// or fields with conflicting paths, then the methods call jsonry.Marshal() and jsonry.Unmarshal()
+// for the whole struct, which uses reflection for every field.
+//
+// Usage:
+//
+//	jsonrygen -type T[,T...] [-output file] [directory]
+//
+// The directory defaults to the current directory, and the output file defaults to
+// <type>_jsonry.go, where <type> is the first type name in lower case.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma-separated list of struct type names; must be set")
+	output := flag.String("output", "", "output file name; default <dir>/<type>_jsonry.go")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: jsonrygen -type T[,T...] [-output file] [directory]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *typeNames == "" || flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if flag.NArg() == 1 {
+		dir = flag.Arg(0)
+	}
+
+	command := strings.Join(append([]string{"jsonrygen"}, os.Args[1:]...), " ")
+	if err := run(dir, strings.Split(*typeNames, ","), *output, command); err != nil {
+		fmt.Fprintf(os.Stderr, "jsonrygen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(dir string, names []string, output, command string) error {
+	if output == "" {
+		output = filepath.Join(dir, strings.ToLower(names[0])+"_jsonry.go")
+	}
+
+	pkg, err := load(dir, filepath.Base(output))
+	if err != nil {
+		return err
+	}
+
+	src, err := generate(pkg, names, command)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(output, src, 0o644)
+}
@@ -0,0 +1,108 @@
+package jsonry
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"code.cloudfoundry.org/jsonry/internal/raw"
+)
+
+// Codec converts values of a specific type to and from JSON. It allows types which cannot have
+// methods added to them, such as types from other modules, to be marshaled and unmarshaled in
+// a custom way. Codecs are specified in Config.Codecs, and take priority over all other behavior.
+type Codec struct {
+	// Type is the Go type that the Codec applies to
+	Type reflect.Type
+
+	// Encode converts a value of the Type into a value that will be converted to JSON in the same
+	// way as encoding/json would, for example a string
+	Encode func(v interface{}) (interface{}, error)
+
+	// Decode converts a decoded JSON value into a value of the Type. JSON values are decoded in
+	// the same way as encoding/json would decode them into an interface{}, except that numbers
+	// are decoded as json.Number. It is not called when the JSON value is null.
+	Decode func(data interface{}) (interface{}, error)
+}
+
+// DurationCodec is a Codec for time.Duration that uses a string such as "1h30m" rather than a
+// number of nanoseconds. It accepts any string that time.ParseDuration() accepts.
+func DurationCodec() Codec {
+	return Codec{
+		Type: reflect.TypeOf(time.Duration(0)),
+		Encode: func(v interface{}) (interface{}, error) {
+			return v.(time.Duration).String(), nil
+		},
+		Decode: func(data interface{}) (interface{}, error) {
+			s, ok := data.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected a string, got: %v", data)
+			}
+			return time.ParseDuration(s)
+		},
+	}
+}
+
+// TimeCodec is a Codec for time.Time that uses the specified layout, for example time.RFC1123 or
+// "2006-01-02". The layout is interpreted in the same way as by time.Parse().
+func TimeCodec(layout string) Codec {
+	return Codec{
+		Type: reflect.TypeOf(time.Time{}),
+		Encode: func(v interface{}) (interface{}, error) {
+			return v.(time.Time).Format(layout), nil
+		},
+		Decode: func(data interface{}) (interface{}, error) {
+			s, ok := data.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected a string, got: %v", data)
+			}
+			return time.Parse(layout, s)
+		},
+	}
+}
+
+func (c *Config) codecFor(t reflect.Type) (Codec, bool) {
+	for _, codec := range c.Codecs {
+		if codec.Type == t {
+			return codec, true
+		}
+	}
+	return Codec{}, false
+}
+
+func marshalWithCodec(codec Codec, in reflect.Value) (interface{}, error) {
+	r, err := codec.Encode(in.Interface())
+	if err != nil {
+		return nil, newForeignError(fmt.Sprintf(`error from codec for "%s"`, codec.Type), err)
+	}
+	return r, nil
+}
+
+func (c *Config) unmarshalWithCodec(codec Codec, target reflect.Value, found bool, source raw.Value) error {
+	switch {
+	case !found:
+		return nil
+	case source.IsNull() && target.Kind() == reflect.Ptr:
+		return setZeroValue(target)
+	case source.IsNull():
+		return nil
+	}
+
+	s, err := decodeSource(source)
+	if err != nil {
+		return err
+	}
+
+	r, err := codec.Decode(s)
+	if err != nil {
+		return newForeignError(fmt.Sprintf(`error from codec for "%s"`, codec.Type), err)
+	}
+
+	v := reflect.ValueOf(r)
+	if !v.IsValid() || !v.Type().AssignableTo(codec.Type) {
+		return newForeignError(fmt.Sprintf(`error from codec for "%s"`, codec.Type), fmt.Errorf("decoded value has type %T", r))
+	}
+
+	c.allocateIfNeeded(target).Set(v)
+	return nil
+}
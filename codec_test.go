@@ -0,0 +1,158 @@
+package jsonry_test
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"time"
+
+	"code.cloudfoundry.org/jsonry"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("codecs", func() {
+	// urlCodec is a Codec for a type from another package that cannot have methods added
+	urlCodec := jsonry.Codec{
+		Type: reflect.TypeOf(url.URL{}),
+		Encode: func(v interface{}) (interface{}, error) {
+			u := v.(url.URL)
+			return u.String(), nil
+		},
+		Decode: func(data interface{}) (interface{}, error) {
+			s, ok := data.(string)
+			if !ok {
+				return nil, errors.New("not a string")
+			}
+			u, err := url.Parse(s)
+			if err != nil {
+				return nil, err
+			}
+			return *u, nil
+		},
+	}
+
+	Describe("DurationCodec", func() {
+		config := jsonry.Config{Codecs: []jsonry.Codec{jsonry.DurationCodec()}}
+
+		type s struct {
+			D time.Duration  `jsonry:"timeouts.health"`
+			P *time.Duration `jsonry:"timeouts.start"`
+			L []time.Duration
+		}
+
+		It("marshals a duration as a string", func() {
+			p := 2 * time.Minute
+			out, err := config.Marshal(s{D: 90 * time.Second, P: &p, L: []time.Duration{time.Millisecond}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(MatchJSON(`{"timeouts":{"health":"1m30s","start":"2m0s"},"L":["1ms"]}`))
+		})
+
+		It("unmarshals a duration from a string", func() {
+			var r s
+			Expect(config.Unmarshal([]byte(`{"timeouts":{"health":"1m30s","start":"2h"},"L":["1ms"]}`), &r)).To(Succeed())
+			Expect(r.D).To(Equal(90 * time.Second))
+			Expect(r.P).To(PointTo(Equal(2 * time.Hour)))
+			Expect(r.L).To(Equal([]time.Duration{time.Millisecond}))
+		})
+
+		It("handles null and missing values", func() {
+			r := s{D: time.Second, P: new(time.Duration)}
+			Expect(config.Unmarshal([]byte(`{"timeouts":{"start":null}}`), &r)).To(Succeed())
+			Expect(r.D).To(Equal(time.Second))
+			Expect(r.P).To(BeNil())
+		})
+
+		It("fails for an invalid duration", func() {
+			var r s
+			err := config.Unmarshal([]byte(`{"timeouts":{"health":"soon"}}`), &r)
+			Expect(err).To(MatchError(`error from codec for "time.Duration" at field "D" (type "time.Duration"): time: invalid duration "soon"`))
+
+			err = config.Unmarshal([]byte(`{"timeouts":{"health":5}}`), &r)
+			Expect(err).To(MatchError(`error from codec for "time.Duration" at field "D" (type "time.Duration"): expected a string, got: 5`))
+		})
+	})
+
+	Describe("TimeCodec", func() {
+		config := jsonry.Config{Codecs: []jsonry.Codec{jsonry.TimeCodec("2006-01-02")}}
+
+		type s struct {
+			T time.Time `jsonry:"metadata.date"`
+		}
+
+		It("marshals and unmarshals using the layout", func() {
+			out, err := config.Marshal(s{T: time.Date(2020, time.March, 4, 0, 0, 0, 0, time.UTC)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(MatchJSON(`{"metadata":{"date":"2020-03-04"}}`))
+
+			var r s
+			Expect(config.Unmarshal(out, &r)).To(Succeed())
+			Expect(r.T).To(Equal(time.Date(2020, time.March, 4, 0, 0, 0, 0, time.UTC)))
+		})
+
+		It("takes priority over json.Marshaler and json.Unmarshaler", func() {
+			var r s
+			err := config.Unmarshal([]byte(`{"metadata":{"date":"2020-03-04T05:06:07Z"}}`), &r)
+			Expect(err).To(MatchError(ContainSubstring(`error from codec for "time.Time" at field "T" (type "time.Time"): parsing time`)))
+		})
+	})
+
+	It("can be a custom codec", func() {
+		config := jsonry.Config{Codecs: []jsonry.Codec{urlCodec}}
+
+		type s struct {
+			U url.URL `jsonry:"links.self"`
+		}
+
+		u, _ := url.Parse("https://example.com/v3/apps?page=2")
+		out, err := config.Marshal(s{U: *u})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(MatchJSON(`{"links":{"self":"https://example.com/v3/apps?page=2"}}`))
+
+		var r s
+		Expect(config.Unmarshal(out, &r)).To(Succeed())
+		Expect(r.U).To(Equal(*u))
+
+		err = config.Unmarshal([]byte(`{"links":{"self":4}}`), &r)
+		Expect(err).To(MatchError(`error from codec for "url.URL" at field "U" (type "url.URL"): not a string`))
+	})
+
+	It("fails when the codec decodes the wrong type", func() {
+		wrong := urlCodec
+		wrong.Decode = func(data interface{}) (interface{}, error) { return data, nil }
+		config := jsonry.Config{Codecs: []jsonry.Codec{wrong}}
+
+		var r struct{ U url.URL }
+		err := config.Unmarshal([]byte(`{"U":"https://example.com"}`), &r)
+		Expect(err).To(MatchError(`error from codec for "url.URL" at field "U" (type "url.URL"): decoded value has type string`))
+	})
+
+	It("records presence for a type with a codec", func() {
+		config := jsonry.Config{Codecs: []jsonry.Codec{{
+			Type:   reflect.TypeOf(recordsPresence{}),
+			Encode: func(v interface{}) (interface{}, error) { return v.(recordsPresence).Name, nil },
+			Decode: func(data interface{}) (interface{}, error) { return recordsPresence{Name: data.(string)}, nil },
+		}}}
+
+		var r struct {
+			A recordsPresence  `jsonry:"a"`
+			B *recordsPresence `jsonry:"b"`
+			C recordsPresence  `jsonry:"c"`
+			D recordsPresence  `jsonry:"d"`
+		}
+		Expect(config.Unmarshal([]byte(`{"a":"x","b":"y","c":null}`), &r)).To(Succeed())
+		Expect(r.A.Name).To(Equal("x"))
+		Expect(r.A.found).To(BeTrue())
+		Expect(r.B.found).To(BeTrue())
+		Expect(r.C.found).To(BeTrue())
+		Expect(r.C.null).To(BeTrue())
+		Expect(r.D.found).To(BeFalse())
+	})
+
+	It("is not used unless it is in the Config", func() {
+		out, err := jsonry.Marshal(struct{ D time.Duration }{D: time.Second})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(MatchJSON(`{"D":1000000000}`))
+	})
+})
@@ -0,0 +1,115 @@
+package jsonry
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"code.cloudfoundry.org/jsonry/internal/raw"
+)
+
+// ComplexEncoding specifies how complex64 and complex128 values are represented in JSON
+type ComplexEncoding int
+
+const (
+	// ComplexUnsupported is the default, and causes complex values to be rejected
+	ComplexUnsupported ComplexEncoding = iota
+	// ComplexArray represents a complex value as a two-element array: [real, imaginary]
+	ComplexArray
+	// ComplexObject represents a complex value as an object: {"real": real, "imag": imaginary}
+	ComplexObject
+)
+
+const (
+	complexRealKey = "real"
+	complexImagKey = "imag"
+)
+
+func (c *Config) marshalComplex(in reflect.Value) (interface{}, error) {
+	v := in.Complex()
+
+	var re, im interface{} = real(v), imag(v)
+	if in.Kind() == reflect.Complex64 {
+		re, im = float32(real(v)), float32(imag(v))
+	}
+
+	switch c.ComplexEncoding {
+	case ComplexArray:
+		return []interface{}{re, im}, nil
+	case ComplexObject:
+		return map[string]interface{}{complexRealKey: re, complexImagKey: im}, nil
+	default:
+		return nil, newUnsupportedTypeError(in.Type())
+	}
+}
+
+func (c *Config) unmarshalIntoComplex(target reflect.Value, found bool, src raw.Value) error {
+	if c.ComplexEncoding == ComplexUnsupported {
+		return newUnsupportedTypeError(target.Type())
+	}
+
+	if !found {
+		return nil
+	}
+
+	source, err := decodeSource(src)
+	if err != nil {
+		return err
+	}
+
+	if target.Kind() == reflect.Ptr {
+		if source == nil {
+			return setZeroValue(target)
+		}
+		target = c.allocateIfNeeded(target)
+	}
+
+	var re, im interface{}
+	switch s := source.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		if c.ComplexEncoding != ComplexArray || len(s) != 2 {
+			return newConversionError(source)
+		}
+		re, im = s[0], s[1]
+	case map[string]interface{}:
+		if c.ComplexEncoding != ComplexObject {
+			return newConversionError(source)
+		}
+		re, im = s[complexRealKey], s[complexImagKey]
+	default:
+		return newConversionError(source)
+	}
+
+	r, err := complexPart(target, re, source)
+	if err != nil {
+		return err
+	}
+
+	i, err := complexPart(target, im, source)
+	if err != nil {
+		return err
+	}
+
+	target.SetComplex(complex(r, i))
+	return nil
+}
+
+func complexPart(target reflect.Value, part, source interface{}) (float64, error) {
+	switch n := part.(type) {
+	case nil:
+		return 0, nil
+	case json.Number:
+		t := reflect.TypeOf(float64(0))
+		if target.Kind() == reflect.Complex64 {
+			t = reflect.TypeOf(float32(0))
+		}
+		f := reflect.New(t).Elem()
+		if err := setFloat(f, n); err != nil {
+			return 0, err
+		}
+		return f.Float(), nil
+	default:
+		return 0, newConversionError(source)
+	}
+}
@@ -0,0 +1,119 @@
+package jsonry
+
+import (
+	"reflect"
+	"unsafe"
+
+	"code.cloudfoundry.org/jsonry/internal/path"
+)
+
+// Config modifies the default behavior of JSONry. The zero value of Config behaves in the same
+// way as the package-level Marshal() and Unmarshal() functions, and each setting is opt-in. For example:
+//
+//	data, err := jsonry.Config{OmitEmpty: true}.Marshal(s)
+//	err = jsonry.Config{AllowIntegralFloats: true}.Unmarshal(data, &receiver)
+//
+// A Config can be reused, and is safe for concurrent use provided that it is not modified.
+type Config struct {
+	// Tags are the struct tag keys that are read to find the location of a field in the JSON, in
+	// priority order. The first tag that is present is used. A "json" tag is always interpreted in
+	// the same way as the standard Go JSON parser would interpret it, and any other tag may contain
+	// "." (period) to denote a nesting hierarchy. The default is "json" followed by "jsonry".
+	// For example, Tags: []string{"jsonry", "json"} gives the "jsonry" tag priority.
+	Tags []string
+
+	// Naming converts a Go field name into a JSON object key when there is no name specified in a tag.
+	// The SnakeCase, CamelCase and KebabCase functions can be used, as can any custom function.
+	// By default the field name is used unchanged.
+	Naming func(string) string
+
+	// DeclarationOrder causes Marshal to write the keys of each JSON object for a struct in the order in
+	// which the fields are declared, rather than in alphabetical order. Where fields have nested paths, the
+	// keys of each nested object are in the order in which they first appear. Keys written by a ",remain"
+	// field or a JSONryMarshaler come afterwards in alphabetical order. Map keys are always alphabetical.
+	DeclarationOrder bool
+
+	// OmitEmpty makes every field behave as if it had been tagged ",omitempty" when marshaling.
+	OmitEmpty bool
+
+	// OmitDefaults causes Marshal to omit a field that has a default value when the value of the field
+	// is equal to the default.
+	OmitDefaults bool
+
+	// UseNumber causes a JSON number to be unmarshaled into an interface{} field as a json.Number
+	// rather than as an int or float64.
+	UseNumber bool
+
+	// ComplexEncoding specifies how complex64 and complex128 values are marshaled and unmarshaled.
+	// By default they are not supported.
+	ComplexEncoding ComplexEncoding
+
+	// Merge causes Unmarshal to update existing values rather than replacing them. Non-nil pointers
+	// are reused, so that fields of a struct behind a pointer which are not in the JSON keep their
+	// values. The entries in the JSON for a map are merged into an existing non-nil map, and are
+	// themselves merged with any existing entry for the same key. Slices are always replaced.
+	// This is useful for applying a partial document, such as a PATCH response, to a cached value.
+	Merge bool
+
+	// AllowIntegralFloats allows a JSON number that has a zero fractional part, such as 3.0 or 4e2,
+	// to be unmarshaled into an int* or uint* field. By default only JSON integers are accepted.
+	AllowIntegralFloats bool
+
+	// Lenient enables type coercion when unmarshaling. A JSON string is accepted for a bool field when
+	// it is "true" or "false", and for an int*, uint* or float* field when it contains a JSON number
+	// without surrounding whitespace, for example "42" or "4.2e1". A JSON number is accepted for a string
+	// field. A single JSON value is accepted for a slice field, and becomes a one-element slice.
+	Lenient bool
+
+	// OnCoercion is called each time that a value is coerced in Lenient mode, once the coerced value
+	// has been set successfully. It can be used to log where the JSON does not match the Go types.
+	OnCoercion func(Coercion)
+
+	// Codecs specify how values of particular types are marshaled and unmarshaled, and take priority
+	// over any other behavior for those types. DurationCodec() and TimeCodec() are provided, and
+	// custom codecs can be written for types which cannot implement the JSON interfaces.
+	Codecs []Codec
+
+	// Unions specify the concrete types for values of interface types, which are identified by a
+	// discriminator in the JSON. Without a Union, an interface is unmarshaled in the same way as
+	// encoding/json would unmarshal into an interface{}.
+	Unions []Union
+
+	// Types are the names of concrete types for fields tagged ",typed". When such a field holds an
+	// interface, the name of the concrete type is written alongside the value, and is used to
+	// find the concrete type when unmarshaling. This also applies to the elements of a slice or map.
+	// The name is written inside the JSON object for the value, so the concrete types must be marshaled
+	// as JSON objects, and Marshal returns an error for a value that is not, such as a string.
+	// For example, with Types: map[string]reflect.Type{"created": reflect.TypeFor[Created]()}:
+	//
+	//	Go: s := struct { E Event `jsonry:"event,typed"` }{E: Created{ID: 1}}
+	//	JSON: {"event": {"$type": "created", "ID": 1}}
+	Types map[string]reflect.Type
+
+	// TypeKey is the key of the name of the concrete type in the JSON object for the value of a field
+	// tagged ",typed". The default is "$type".
+	TypeKey string
+
+	// readPlans are the read plans for one call to Unmarshal, which are used when the plans
+	// cannot be shared between calls
+	readPlans map[reflect.Type]*readPlan
+
+	// unionNames are the discriminator values for the concrete types of each Union for one
+	// call to Marshal, keyed by the Variants map
+	unionNames map[unsafe.Pointer]map[reflect.Type]string
+}
+
+// Coercion describes a type conversion made when unmarshaling in Lenient mode.
+type Coercion struct {
+	// Value is the JSON value that was coerced
+	Value interface{}
+	// Type is the Go type that the value was coerced into
+	Type reflect.Type
+	// Path is the path of the value from the receiver, in the same form as in error messages,
+	// for example Foo.Bar[3]["k"]. It is empty when the receiver itself was coerced.
+	Path string
+}
+
+func (c *Config) computePath(field reflect.StructField) path.Path {
+	return path.Options{Tags: c.Tags, Naming: c.Naming}.ComputePath(field)
+}
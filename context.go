@@ -0,0 +1,166 @@
+package jsonry
+
+import (
+	"fmt"
+	"reflect"
+
+	"code.cloudfoundry.org/jsonry/internal/path"
+	"code.cloudfoundry.org/jsonry/internal/raw"
+	"code.cloudfoundry.org/jsonry/internal/tree"
+)
+
+// Context describes where in the JSON a value is being marshaled or unmarshaled
+type Context struct {
+	// Path is the JSONry path of the field, for example "relationships.space.data"
+	Path string
+
+	// Key is the last part of the path, which is the key of the value in the Parent, for example "data"
+	Key string
+
+	// Options are the options from the struct tag, for example "omitempty"
+	Options []string
+
+	// Parent is the JSON object that contains the value, which is the object at the path without
+	// its last part, for example the object at "relationships.space". When marshaling, keys may be
+	// added to it, for example so that a type can write several keys at once. When unmarshaling, it
+	// is the decoded JSON object, with numbers decoded as json.Number. It is nil for the elements of
+	// a slice, array or map, when the path has a list hint, and when unmarshaling if the object is
+	// not in the JSON.
+	Parent map[string]interface{}
+
+	// Config is the configuration being used to marshal or unmarshal
+	Config Config
+}
+
+// JSONryMarshaler is the interface implemented by types that marshal themselves with knowledge of
+// where they are in the JSON. The value returned by MarshalJSONry() is written at the path of the field,
+// and is converted to JSON in the same way as encoding/json would. It takes priority over json.Marshaler.
+type JSONryMarshaler interface {
+	MarshalJSONry(ctx Context) (interface{}, error)
+}
+
+// JSONryUnmarshaler is the interface implemented by types that unmarshal themselves with knowledge of
+// where they are in the JSON. The UnmarshalJSONry() method is called with the JSON at the path of the field
+// exactly as it appears in the input, and is not called when the path is not in the input. It takes priority
+// over json.Unmarshaler.
+type JSONryUnmarshaler interface {
+	UnmarshalJSONry(ctx Context, data []byte) error
+}
+
+var (
+	jsonryMarshalerType   = reflect.TypeOf((*JSONryMarshaler)(nil)).Elem()
+	jsonryUnmarshalerType = reflect.TypeOf((*JSONryUnmarshaler)(nil)).Elem()
+)
+
+// sourceObject is the JSON object for a struct that is being unmarshaled. It is only decoded
+// when a field needs it for a Context, and then only once for the struct.
+type sourceObject struct {
+	source  raw.Value
+	decoded tree.Tree
+	err     error
+	done    bool
+}
+
+func (s *sourceObject) decode() (tree.Tree, error) {
+	if !s.done {
+		var v interface{}
+		v, s.err = decodeSource(s.source)
+		m, _ := v.(map[string]interface{})
+		s.decoded = m
+		s.done = true
+	}
+	return s.decoded, s.err
+}
+
+func (c *Config) newContext(opts tagOptions) (Context, error) {
+	ctx := Context{
+		Path:    opts.path.String(),
+		Options: opts.path.TagOptions(),
+		Config:  *c,
+	}
+	ctx.Config.readPlans, ctx.Config.unionNames = nil, nil // these are only for the current call
+
+	if opts.path.Len() == 0 {
+		return ctx, nil
+	}
+
+	stem, leaf := opts.path.Split()
+	ctx.Key = leaf.Name
+
+	switch {
+	case opts.parent != nil:
+		ctx.Parent, _ = opts.parent.Branch(stem)
+	case opts.object != nil:
+		obj, err := opts.object.decode()
+		if err != nil {
+			return Context{}, err
+		}
+		ctx.Parent = objectAt(obj, stem)
+	}
+
+	return ctx, nil
+}
+
+// objectAt finds the decoded JSON object at the path, or nil if it is not there
+func objectAt(obj tree.Tree, p path.Path) map[string]interface{} {
+	if p.Len() == 0 {
+		return obj
+	}
+
+	v, _ := obj.Fetch(p)
+	m, _ := v.(map[string]interface{})
+	return m
+}
+
+// usesContext reports whether unmarshaling a type may need a Context, so that the JSON
+// object for a struct is only kept for decoding when a field could use it
+func usesContext(t reflect.Type) bool {
+	for {
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		switch {
+		case reflect.PointerTo(t).Implements(jsonryUnmarshalerType):
+			return true
+		case reflect.PointerTo(t).Implements(optionalSetterType):
+			t = reflect.New(t).Interface().(optionalSetter).optionalType()
+		default:
+			return false
+		}
+	}
+}
+
+func (c *Config) marshalJSONryMarshaler(in reflect.Value, opts tagOptions) (interface{}, error) {
+	const method = "MarshalJSONry"
+	ctx, err := c.newContext(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := in.Interface().(JSONryMarshaler).MarshalJSONry(ctx)
+	if err != nil {
+		return nil, newForeignError(fmt.Sprintf("error from %s() call", method), err)
+	}
+
+	return r, nil
+}
+
+func (c *Config) unmarshalIntoJSONryUnmarshaler(target reflect.Value, found bool, source raw.Value, opts tagOptions) error {
+	const method = "UnmarshalJSONry"
+	if !found {
+		return nil
+	}
+
+	ctx, err := c.newContext(opts)
+	if err != nil {
+		return err
+	}
+
+	u := c.allocateIfNeeded(target).Addr().Interface().(JSONryUnmarshaler)
+	if err := u.UnmarshalJSONry(ctx, []byte(source)); err != nil {
+		return newForeignError(fmt.Sprintf("error from %s() call", method), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package jsonry
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"code.cloudfoundry.org/jsonry/internal/path"
+	"code.cloudfoundry.org/jsonry/internal/raw"
+)
+
+// readPlans are shared between calls to Unmarshal
+var readPlans sync.Map
+
+// readPlan records how the fields of a struct type are read from a JSON object, so that the
+// JSON is walked once for each struct
+type readPlan struct {
+	fields []readField
+	paths  *raw.Paths
+	rest   *remainder
+	// contexts is set when a field may need the JSON object for a Context
+	contexts bool
+}
+
+type readField struct {
+	index int
+	name  string
+	typ   reflect.Type
+	opts  tagOptions
+	// def is the JSON for the default value, when there is one
+	def raw.Value
+}
+
+func (c *Config) readPlan(t reflect.Type) *readPlan {
+	// Plans depend on the Config, so can only be shared between calls when the Config
+	// can be compared, which excludes a Naming function
+	if c.Naming != nil {
+		if p, ok := c.readPlans[t]; ok {
+			return p
+		}
+		p := c.newReadPlan(t)
+		if c.readPlans != nil {
+			c.readPlans[t] = p
+		}
+		return p
+	}
+
+	key := planKey{typ: t, tags: strings.Join(c.Tags, ",")}
+	if p, ok := readPlans.Load(key); ok {
+		return p.(*readPlan)
+	}
+
+	p := c.newReadPlan(t)
+	readPlans.Store(key, p)
+	return p
+}
+
+func (c *Config) newReadPlan(t reflect.Type) *readPlan {
+	p := &readPlan{}
+	var paths []path.Path
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !public(f) {
+			continue
+		}
+
+		fp := c.computePath(f)
+		if fp.Option(remainOption) {
+			p.rest = &remainder{index: i, path: fp}
+			continue
+		}
+
+		paths = append(paths, fp)
+		p.contexts = p.contexts || usesContext(f.Type)
+		p.fields = append(p.fields, readField{index: i, name: f.Name, typ: f.Type, opts: newTagOptions(fp), def: defaultSource(fp, f.Type)})
+	}
+
+	p.paths = raw.NewPaths(paths)
+	return p
+}
+
+// read finds the value for each field in the object. It returns the members that are not
+// read by any field when the struct has a remainder field.
+func (p *readPlan) read(source raw.Value) ([]raw.Value, raw.Object, error) {
+	if p.rest == nil {
+		return p.paths.Read(source), nil, nil
+	}
+
+	values, rest, err := p.paths.ReadRest(source)
+	if err != nil {
+		return nil, nil, newForeignError("error decoding JSON", err)
+	}
+	return values, rest, nil
+}
+
+func (c *Config) unmarshalIntoStruct(target reflect.Value, found bool, source raw.Value, at *location) error {
+	if !found || source.IsNull() {
+		return nil
+	}
+
+	if len(source) == 0 || source[0] != '{' {
+		return newSourceConversionError(source)
+	}
+
+	target = c.allocateIfNeeded(target)
+	p := c.readPlan(target.Type())
+	values, rest, err := p.read(source)
+	if err != nil {
+		return err
+	}
+
+	var object *sourceObject
+	if p.contexts {
+		object = &sourceObject{source: source}
+	}
+
+	for i, f := range p.fields {
+		opts := f.opts
+		opts.object = object
+		opts.at = c.locate(at, location{sort: fieldLocation, name: f.name, typ: f.typ})
+
+		value, found := values[i], len(values[i]) > 0
+		if !found && f.def != nil && !c.Merge {
+			value, found = f.def, true
+		}
+
+		if err := c.unmarshal(target.Field(f.index), found, value, opts); err != nil {
+			return wrapErrorWithFieldContext(err, f.name, f.typ)
+		}
+	}
+
+	if p.rest != nil {
+		return c.unmarshalRemainder(target, rest, *p.rest, at)
+	}
+
+	return nil
+}
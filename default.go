@@ -0,0 +1,99 @@
+package jsonry
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"code.cloudfoundry.org/jsonry/internal/path"
+	"code.cloudfoundry.org/jsonry/internal/raw"
+)
+
+// defaultSource is the JSON for the default value of a field, which is unmarshaled in the same way as
+// a value in the input. The default is used as it is when it is valid JSON, for example 42 or ["a"],
+// and otherwise is a JSON string. The default for a string field is always a JSON string, so that
+// `default:"42"` is the string "42".
+func defaultSource(p path.Path, t reflect.Type) raw.Value {
+	if !p.HasDefault {
+		return nil
+	}
+
+	if valueType(t).Kind() != reflect.String && json.Valid([]byte(p.Default)) {
+		if v, err := raw.Parse([]byte(p.Default)); err == nil {
+			return v
+		}
+	}
+
+	b, _ := json.Marshal(p.Default)
+	return raw.Value(b)
+}
+
+// valueType finds the type of the value that a default is unmarshaled into, behind any pointers and Optionals
+func valueType(t reflect.Type) reflect.Type {
+	for {
+		switch {
+		case t.Kind() == reflect.Ptr:
+			t = t.Elem()
+		case reflect.PointerTo(t).Implements(optionalSetterType):
+			t = reflect.New(t).Interface().(optionalSetter).optionalType()
+		default:
+			return t
+		}
+	}
+}
+
+// decodeDefaults unmarshals the default values of the fields of a struct type by field index, so that
+// Config.OmitDefaults can compare each field with its default. The values are unmarshaled without
+// Lenient, OnCoercion and Merge, which only apply to the input of Unmarshal, and a default that
+// cannot be unmarshaled is left out, so that the field is not omitted.
+func (c *Config) decodeDefaults(t reflect.Type) map[int]reflect.Value {
+	d := *c
+	d.Lenient, d.OnCoercion, d.Merge = false, nil, false
+
+	var defaults map[int]reflect.Value
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !public(f) {
+			continue
+		}
+
+		fp := c.computePath(f)
+		if !fp.HasDefault {
+			continue
+		}
+
+		v := reflect.New(f.Type).Elem()
+		if err := d.unmarshal(v, true, defaultSource(fp, f.Type), newTagOptions(fp)); err != nil {
+			continue
+		}
+
+		if defaults == nil {
+			defaults = make(map[int]reflect.Value)
+		}
+		defaults[i] = v
+	}
+
+	return defaults
+}
+
+// defaultSettings are the settings which change the default values unmarshaled by decodeDefaults,
+// and which can be compared, so that plans are only shared between calls with the same settings
+type defaultSettings struct {
+	omit           bool
+	useNumber      bool
+	complex        ComplexEncoding
+	integralFloats bool
+	typeKey        string
+}
+
+func (c *Config) defaultSettings() defaultSettings {
+	if !c.OmitDefaults {
+		return defaultSettings{}
+	}
+	return defaultSettings{
+		omit:           true,
+		useNumber:      c.UseNumber,
+		complex:        c.ComplexEncoding,
+		integralFloats: c.AllowIntegralFloats,
+		typeKey:        c.TypeKey,
+	}
+}
@@ -0,0 +1,153 @@
+package jsonry_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/jsonry"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("defaults", func() {
+	type lifecycle struct {
+		Type  string   `jsonry:"lifecycle.type,default=buildpack"`
+		Stack *string  `jsonry:"lifecycle.data.stack" default:"cflinuxfs4"`
+		Packs []string `jsonry:"lifecycle.data.buildpacks" default:"[\"go_buildpack\"]"`
+	}
+
+	type s struct {
+		Name      string            `jsonry:"name,default=42"`
+		Instances int               `jsonry:"process.instances,default=1"`
+		Ratio     float64           `jsonry:"ratio,default=0.5"`
+		Enabled   bool              `jsonry:"enabled,default=true"`
+		Labels    map[string]string `default:"{\"a\":\"b\"}"`
+		Lifecycle lifecycle         `jsonry:"app"`
+		Timeout   time.Duration     `jsonry:"timeout,default=1m"`
+	}
+
+	Describe("unmarshaling", func() {
+		It("uses the default when the path is not in the JSON", func() {
+			var r s
+			config := jsonry.Config{Codecs: []jsonry.Codec{jsonry.DurationCodec()}}
+			Expect(config.Unmarshal([]byte(`{"process":{},"app":{"lifecycle":{"data":{}}}}`), &r)).To(Succeed())
+
+			Expect(r).To(MatchAllFields(Fields{
+				"Name":      Equal("42"),
+				"Instances": Equal(1),
+				"Ratio":     Equal(0.5),
+				"Enabled":   BeTrue(),
+				"Labels":    Equal(map[string]string{"a": "b"}),
+				"Lifecycle": MatchAllFields(Fields{
+					"Type":  Equal("buildpack"),
+					"Stack": PointTo(Equal("cflinuxfs4")),
+					"Packs": Equal([]string{"go_buildpack"}),
+				}),
+				"Timeout": Equal(time.Minute),
+			}))
+		})
+
+		It("uses a default that looks like JSON as a string for an Optional or pointer string", func() {
+			var r struct {
+				A jsonry.Optional[string]  `jsonry:"a,default=42"`
+				B *jsonry.Optional[string] `jsonry:"b,default=true"`
+				C jsonry.Optional[*string] `jsonry:"c,default=[1]"`
+				D jsonry.Optional[int]     `jsonry:"d,default=42"`
+			}
+			Expect(jsonry.Unmarshal([]byte(`{}`), &r)).To(Succeed())
+			Expect(r.A).To(Equal(jsonry.Some("42")))
+			Expect(r.B).To(PointTo(Equal(jsonry.Some("true"))))
+			Expect(r.D).To(Equal(jsonry.Some(42)))
+
+			c, ok := r.C.Get()
+			Expect(ok).To(BeTrue())
+			Expect(c).To(PointTo(Equal("[1]")))
+		})
+
+		It("uses the value in the JSON, including null", func() {
+			var r lifecycle
+			Expect(jsonry.Unmarshal([]byte(`{"lifecycle":{"type":"docker","data":{"stack":null,"buildpacks":[]}}}`), &r)).To(Succeed())
+			Expect(r).To(Equal(lifecycle{Type: "docker", Packs: []string{}}))
+		})
+
+		It("does not use the default when merging", func() {
+			r := lifecycle{Type: "docker"}
+			Expect(jsonry.Config{Merge: true}.Unmarshal([]byte(`{}`), &r)).To(Succeed())
+			Expect(r).To(Equal(lifecycle{Type: "docker"}))
+		})
+
+		It("fails when the default cannot be unmarshaled", func() {
+			var r struct {
+				I int `jsonry:"i,default=lots"`
+			}
+			err := jsonry.Unmarshal([]byte(`{}`), &r)
+			Expect(err).To(MatchError(`cannot unmarshal "lots" type "string" into field "I" (type "int")`))
+		})
+	})
+
+	Describe("marshaling", func() {
+		It("writes fields with default values", func() {
+			out, err := jsonry.Marshal(lifecycle{Type: "buildpack"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(MatchJSON(`{"lifecycle":{"type":"buildpack","data":{"stack":null,"buildpacks":null}}}`))
+		})
+
+		It("omits fields equal to their defaults when OmitDefaults is set", func() {
+			stack := "cflinuxfs4"
+			config := jsonry.Config{OmitDefaults: true}
+
+			out, err := config.Marshal(lifecycle{Type: "buildpack", Stack: &stack, Packs: []string{"go_buildpack"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(MatchJSON(`{}`))
+
+			other := "cflinuxfs3"
+			out, err = config.Marshal(lifecycle{Type: "docker", Stack: &other, Packs: []string{"go_buildpack", "java_buildpack"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(MatchJSON(`{"lifecycle":{"type":"docker","data":{"stack":"cflinuxfs3","buildpacks":["go_buildpack","java_buildpack"]}}}`))
+		})
+
+		It("unmarshals the default values with the Codecs of the Config", func() {
+			type t struct {
+				Timeout time.Duration `jsonry:"timeout,default=1m"`
+			}
+
+			config := jsonry.Config{OmitDefaults: true, Codecs: []jsonry.Codec{jsonry.DurationCodec()}}
+			out, err := config.Marshal(t{Timeout: time.Minute})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(MatchJSON(`{}`))
+
+			out, err = jsonry.Config{OmitDefaults: true}.Marshal(t{Timeout: time.Minute})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(MatchJSON(`{"timeout":60000000000}`))
+		})
+
+		It("does not use the settings for unmarshaling the input", func() {
+			type t struct {
+				Count int `jsonry:"count" default:"\"3\""`
+			}
+
+			var coercions []jsonry.Coercion
+			config := jsonry.Config{
+				OmitDefaults: true,
+				Lenient:      true,
+				OnCoercion:   func(c jsonry.Coercion) { coercions = append(coercions, c) },
+			}
+
+			out, err := config.Marshal(t{Count: 3})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(MatchJSON(`{"count":3}`))
+			Expect(coercions).To(BeEmpty())
+		})
+
+		It("omits fields equal to their defaults in a struct built as a tree", func() {
+			type t struct {
+				Type  string                 `jsonry:"type,default=buildpack"`
+				Other map[string]interface{} `jsonry:",remain"`
+			}
+
+			out, err := jsonry.Config{OmitDefaults: true}.Marshal(t{Type: "buildpack", Other: map[string]interface{}{"a": 1}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(MatchJSON(`{"a":1}`))
+		})
+	})
+})
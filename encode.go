@@ -0,0 +1,530 @@
+package jsonry
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"code.cloudfoundry.org/jsonry/internal/path"
+	"code.cloudfoundry.org/jsonry/internal/tree"
+	"code.cloudfoundry.org/jsonry/internal/write"
+)
+
+var (
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	numberType        = reflect.TypeOf(json.Number(""))
+)
+
+// encoder writes JSON directly to a buffer, and is the only place where the way that a type is
+// marshaled is decided. The JSON objects for structs are written according to a plan, which groups
+// fields by the common prefixes of their paths.
+type encoder struct {
+	config *Config
+	buf    bytes.Buffer
+	plans  map[reflect.Type]*plan // only used when plans cannot be shared
+}
+
+// plan records how the fields of a struct type are written as a JSON object
+// plans are shared between calls to Marshal
+var plans sync.Map
+
+type planKey struct {
+	typ      reflect.Type
+	tags     string
+	order    bool
+	defaults defaultSettings
+}
+
+type plan struct {
+	// fallback is set when the object must be built as a tree, for example because a field
+	// adds keys to the parent object, or fields have conflicting paths
+	fallback bool
+	// dynamic lists the fields which must be checked when writing because they are interfaces
+	dynamic []int
+	members []*planNode
+	// defaults are the default values by field index, when Config.OmitDefaults is set
+	defaults map[int]reflect.Value
+}
+
+// planNode is a key in the JSON object. It either has a field, or has members
+type planNode struct {
+	name    string
+	field   *planField
+	members []*planNode
+}
+
+type planField struct {
+	index int
+	name  string
+	typ   reflect.Type
+	path  path.Path
+	// spread is the part of the path from a list hint, when there is one
+	spread path.Path
+}
+
+// encode writes the input, which is written as a JSON object using its JSONry paths when it is a struct,
+// even if it implements one of the interfaces which would otherwise change how it is marshaled
+func (c *Config) encode(in reflect.Value) ([]byte, error) {
+	if len(c.Unions) > 0 || len(c.Types) > 0 {
+		c.unionNames = make(map[unsafe.Pointer]map[reflect.Type]string)
+	}
+
+	e := encoder{config: c}
+
+	var err error
+	if in.Kind() == reflect.Struct {
+		err = e.encodeStruct(in)
+	} else {
+		err = e.encode(in, tagOptions{})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return e.buf.Bytes(), nil
+}
+
+func (e *encoder) encode(in reflect.Value, opts tagOptions) error {
+	input := reflect.Indirect(in)
+	kind := input.Kind()
+
+	if kind != reflect.Invalid {
+		if codec, ok := e.config.codecFor(input.Type()); ok {
+			r, err := marshalWithCodec(codec, input)
+			if err != nil {
+				return err
+			}
+			return e.writeInterface(r)
+		}
+		if union, ok := e.config.unionFor(input.Type(), opts); ok {
+			r, err := e.config.marshalUnion(union, input, opts)
+			if err != nil {
+				return err
+			}
+			return e.writeInterface(r)
+		}
+	}
+
+	switch {
+	case kind == reflect.Invalid:
+		e.buf.WriteString("null")
+		return nil
+	case input.Type().Implements(jsonryMarshalerType):
+		r, err := e.config.marshalJSONryMarshaler(input, opts)
+		if err != nil {
+			return err
+		}
+		return e.writeInterface(r)
+	case input.Type() == rawMessageType:
+		r, err := marshalRawMessage(input)
+		if err != nil {
+			return err
+		}
+		return e.writeRaw(r)
+	case input.Type().Implements(optionalValuerType):
+		v, ok := input.Interface().(optionalValuer).optionalValue()
+		if !ok {
+			e.buf.WriteString("null")
+			return nil
+		}
+		return e.encode(v, opts)
+	case input.Type().Implements(jsonMarshalerType):
+		r, err := marshalJSONMarshaler(input)
+		if err != nil {
+			return err
+		}
+		return e.writeRaw(r)
+	case kind == reflect.Interface:
+		return e.encode(input.Elem(), opts)
+	case basicType(kind):
+		return e.writeBasic(in, input)
+	case complexType(kind):
+		r, err := e.config.marshalComplex(input)
+		if err != nil {
+			return err
+		}
+		return e.writeInterface(r)
+	case kind == reflect.Struct:
+		return e.encodeStruct(input)
+	case byteSlice(input.Type()):
+		return e.writeInterface(marshalBytes(input, opts))
+	case kind == reflect.Slice || kind == reflect.Array:
+		return e.encodeList(input, opts)
+	case kind == reflect.Map:
+		return e.encodeMap(input, opts)
+	default:
+		return newUnsupportedTypeError(input.Type())
+	}
+}
+
+func (e *encoder) encodeStruct(in reflect.Value) error {
+	p := e.plan(in.Type())
+	if p.fallback || holdsJSONryMarshaler(in, p.dynamic) {
+		r, err := e.config.marshalStruct(in, p.defaults)
+		if err != nil {
+			return err
+		}
+		return e.writeInterface(r)
+	}
+
+	e.buf.WriteByte('{')
+	if _, err := e.encodeMembers(in, p, p.members); err != nil {
+		return err
+	}
+	e.buf.WriteByte('}')
+	return nil
+}
+
+// encodeMembers writes the keys and values of an object, and reports whether any were written.
+// A key with members is only written when at least one of its members is written, in the
+// same way that tree.Attach() only creates branches that lead to a value.
+func (e *encoder) encodeMembers(in reflect.Value, p *plan, members []*planNode) (written bool, err error) {
+	for _, m := range members {
+		mark := e.buf.Len()
+		if written {
+			e.buf.WriteByte(',')
+		}
+		e.writeString(m.name)
+		e.buf.WriteByte(':')
+
+		var ok bool
+		if m.field != nil {
+			ok, err = e.encodeField(in, p, m)
+		} else {
+			e.buf.WriteByte('{')
+			ok, err = e.encodeMembers(in, p, m.members)
+			e.buf.WriteByte('}')
+		}
+
+		switch {
+		case err != nil:
+			return false, err
+		case ok:
+			written = true
+		default:
+			e.buf.Truncate(mark)
+		}
+	}
+
+	return written, nil
+}
+
+func (e *encoder) encodeField(in reflect.Value, p *plan, m *planNode) (bool, error) {
+	f := m.field
+	val := in.Field(f.index)
+	if !e.config.shouldMarshal(f.path, val, p.defaults[f.index]) {
+		return false, nil
+	}
+
+	if f.spread.Len() == 0 {
+		if err := e.encode(val, newTagOptions(f.path)); err != nil {
+			return false, wrapErrorWithFieldContext(err, f.name, f.typ)
+		}
+		return true, nil
+	}
+
+	r, err := e.config.marshal(val, newTagOptions(f.path))
+	if err != nil {
+		return false, wrapErrorWithFieldContext(err, f.name, f.typ)
+	}
+
+	// The list is attached to a tree so that it is spread in exactly the same way,
+	// and then the value is taken from the single member of the JSON object
+	segment, _ := f.spread.Pull()
+	if err := e.writeInterface(make(tree.Tree).Attach(f.spread, r)[segment.Name]); err != nil {
+		return false, wrapErrorWithFieldContext(err, f.name, f.typ)
+	}
+	return true, nil
+}
+
+func (e *encoder) encodeList(in reflect.Value, opts tagOptions) error {
+	if in.Kind() == reflect.Slice && in.IsNil() {
+		e.buf.WriteString("null")
+		return nil
+	}
+
+	e.buf.WriteByte('[')
+	for i := 0; i < in.Len(); i++ {
+		if i > 0 {
+			e.buf.WriteByte(',')
+		}
+		if err := e.encode(in.Index(i), opts.element()); err != nil {
+			return wrapErrorWithIndexContext(err, i, in.Type())
+		}
+	}
+	e.buf.WriteByte(']')
+	return nil
+}
+
+func (e *encoder) encodeMap(in reflect.Value, opts tagOptions) error {
+	if in.IsNil() {
+		e.buf.WriteString("null")
+		return nil
+	}
+
+	if in.Type().Key().Kind() != reflect.String {
+		if in.Len() == 0 {
+			e.buf.WriteString("{}")
+			return nil
+		}
+		return newUnsupportedKeyTypeError(in.Type())
+	}
+
+	keys := in.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+	e.buf.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			e.buf.WriteByte(',')
+		}
+		e.writeString(k.String())
+		e.buf.WriteByte(':')
+		if err := e.encode(in.MapIndex(k), opts.element()); err != nil {
+			return wrapErrorWithKeyContext(err, k.String(), k.Type())
+		}
+	}
+	e.buf.WriteByte('}')
+	return nil
+}
+
+func (e *encoder) plan(t reflect.Type) *plan {
+	// Plans depend on the Config, so can only be shared between calls when the Config
+	// can be compared, which excludes a Naming function, and the Codecs, Unions and Types
+	// which may be used to unmarshal the default values for OmitDefaults
+	c := e.config
+	if c.Naming == nil && !(c.OmitDefaults && (len(c.Codecs) > 0 || len(c.Unions) > 0 || len(c.Types) > 0)) {
+		key := planKey{typ: t, tags: strings.Join(c.Tags, ","), order: c.DeclarationOrder, defaults: c.defaultSettings()}
+		if p, ok := plans.Load(key); ok {
+			return p.(*plan)
+		}
+		p := c.newPlan(t)
+		plans.Store(key, p)
+		return p
+	}
+
+	if p, ok := e.plans[t]; ok {
+		return p
+	}
+	if e.plans == nil {
+		e.plans = make(map[reflect.Type]*plan)
+	}
+	p := c.newPlan(t)
+	e.plans[t] = p
+	return p
+}
+
+func (c *Config) newPlan(t reflect.Type) *plan {
+	p := &plan{}
+	if c.OmitDefaults {
+		p.defaults = c.decodeDefaults(t)
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !public(f) {
+			continue
+		}
+
+		fp := c.computePath(f)
+		switch {
+		case fp.OmitAlways:
+			continue
+		case fp.Option(remainOption), mayImplementJSONryMarshaler(f.Type):
+			p.fallback = true
+			return p
+		case underlyingKind(f.Type) == reflect.Interface:
+			p.dynamic = append(p.dynamic, i)
+		}
+
+		if !p.add(planField{index: i, name: f.Name, typ: f.Type, path: fp}) {
+			p.fallback = true
+			return p
+		}
+	}
+
+	if !c.DeclarationOrder {
+		p.sort(p.members)
+	}
+
+	return p
+}
+
+// add inserts the field into the plan, and reports false when the path conflicts with
+// another field, so that the output would depend on the order in which fields are attached
+func (p *plan) add(f planField) bool {
+	members := &p.members
+	rest := f.path
+
+	for {
+		segment, stem := rest.Pull()
+		var existing *planNode
+		for _, m := range *members {
+			if m.name == segment.Name {
+				existing = m
+			}
+		}
+
+		switch {
+		case stem.Len() == 0 || segment.List:
+			if existing != nil {
+				return false
+			}
+			if stem.Len() > 0 {
+				f.spread = rest
+			}
+			*members = append(*members, &planNode{name: segment.Name, field: &f})
+			return true
+		case existing == nil:
+			existing = &planNode{name: segment.Name}
+			*members = append(*members, existing)
+		case existing.field != nil:
+			return false
+		}
+
+		members = &existing.members
+		rest = stem
+	}
+}
+
+func (p *plan) sort(members []*planNode) {
+	sort.Slice(members, func(i, j int) bool { return members[i].name < members[j].name })
+	for _, m := range members {
+		p.sort(m.members)
+	}
+}
+
+// mayImplementJSONryMarshaler identifies types where a JSONryMarshaler may be called with
+// the parent object, including when the type is an Optional that holds a JSONryMarshaler
+func mayImplementJSONryMarshaler(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t.Implements(jsonryMarshalerType):
+		return true
+	case reflect.PointerTo(t).Implements(optionalSetterType):
+		return mayImplementJSONryMarshaler(reflect.New(t).Interface().(optionalSetter).optionalType())
+	default:
+		return false
+	}
+}
+
+// holdsJSONryMarshaler checks whether any of the interface fields hold a JSONryMarshaler,
+// following the same steps as encoder.encode()
+func holdsJSONryMarshaler(in reflect.Value, fields []int) bool {
+	for _, i := range fields {
+		v := in.Field(i)
+		for {
+			v = reflect.Indirect(v)
+			if !v.IsValid() {
+				break
+			}
+			if v.Type().Implements(jsonryMarshalerType) {
+				return true
+			}
+			if v.Type().Implements(optionalValuerType) {
+				var ok bool
+				if v, ok = v.Interface().(optionalValuer).optionalValue(); !ok {
+					break
+				}
+				continue
+			}
+			if v.Kind() != reflect.Interface {
+				break
+			}
+			v = v.Elem()
+		}
+	}
+	return false
+}
+
+func implementsAny(t reflect.Type, interfaces ...reflect.Type) bool {
+	for _, i := range interfaces {
+		if t.Implements(i) {
+			return true
+		}
+	}
+	return false
+}
+
+func underlyingKind(t reflect.Type) reflect.Kind {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem().Kind()
+	}
+	return t.Kind()
+}
+
+// writeInterface writes any value in the same way as json.Marshal()
+func (e *encoder) writeInterface(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	e.buf.Write(b)
+	return nil
+}
+
+// writeRaw writes JSON which is known to be valid in the same way as json.Marshal() would
+// write the output of a MarshalJSON() method, which is compacted and HTML escaped
+func (e *encoder) writeRaw(r interface{}) error {
+	b, ok := r.(json.RawMessage)
+	if !ok {
+		return e.writeInterface(r)
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, b); err != nil {
+		return err
+	}
+	json.HTMLEscape(&e.buf, compact.Bytes())
+	return nil
+}
+
+// writeBasic writes a string, bool, int*, uint* or float* in the same way as json.Marshal()
+func (e *encoder) writeBasic(in, input reflect.Value) error {
+	if implementsAny(in.Type(), textMarshalerType, jsonMarshalerType) || implementsAny(input.Type(), textMarshalerType) {
+		return e.writeInterface(in.Interface())
+	}
+
+	// json.Marshal() checks that a json.Number is valid and writes it as a number rather than a string
+	if input.Type() == numberType {
+		return e.writeInterface(input.Interface())
+	}
+
+	var b [64]byte
+	switch input.Kind() {
+	case reflect.String:
+		e.writeString(input.String())
+	case reflect.Bool:
+		e.buf.Write(strconv.AppendBool(b[:0], input.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e.buf.Write(strconv.AppendInt(b[:0], input.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		e.buf.Write(strconv.AppendUint(b[:0], input.Uint(), 10))
+	case reflect.Float32:
+		return e.writeFloat(input.Float(), 32)
+	case reflect.Float64:
+		return e.writeFloat(input.Float(), 64)
+	}
+	return nil
+}
+
+// writeFloat fails with the same error as json.Marshal() for values that JSON cannot represent
+func (e *encoder) writeFloat(f float64, bits int) error {
+	if !write.Float(&e.buf, f, bits) {
+		return &json.UnsupportedValueError{Value: reflect.ValueOf(f), Str: strconv.FormatFloat(f, 'g', -1, bits)}
+	}
+	return nil
+}
+
+func (e *encoder) writeString(s string) {
+	write.String(&e.buf, s)
+}
@@ -0,0 +1,80 @@
+package jsonry
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"code.cloudfoundry.org/jsonry/internal/path"
+	"code.cloudfoundry.org/jsonry/internal/raw"
+	"code.cloudfoundry.org/jsonry/internal/tree"
+)
+
+// MarshalAt works in the same way as Marshal(), except that the JSON for the input is written at the
+// specified JSONry path, so that there is no need to create a struct for an envelope. For example:
+//
+//	data, err := jsonry.MarshalAt(app, "data.attributes")
+//
+// Will generate {"data":{"attributes":{...}}}. The path may contain a list hint, in which case a slice
+// input is spread across the elements of the list. An empty path is the same as calling Marshal().
+func MarshalAt(in interface{}, path string) ([]byte, error) {
+	return Config{}.MarshalAt(in, path)
+}
+
+// MarshalAt works in the same way as the package-level MarshalAt() function, but using the
+// settings from the Config.
+func (c Config) MarshalAt(in interface{}, p string) ([]byte, error) {
+	if p == "" {
+		return c.Marshal(in)
+	}
+
+	v := reflect.ValueOf(in)
+	if iv := reflect.Indirect(v); iv.Kind() == reflect.Struct {
+		v = iv
+	}
+
+	m, err := c.encode(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(make(tree.Tree).Attach(path.Parse(p), json.RawMessage(m)))
+}
+
+// UnmarshalAt works in the same way as Unmarshal(), except that the receiver is set from the value at the
+// specified JSONry path, so that there is no need to create a struct for an envelope. For example:
+//
+//	var apps []App
+//	err := jsonry.UnmarshalAt(data, "resources", &apps)
+//
+// The path may contain a list hint, in which case the values are collected into a list. When the path
+// is not in the JSON, the receiver is not modified. An empty path is the same as calling Unmarshal().
+func UnmarshalAt(data []byte, path string, receiver interface{}) error {
+	return Config{}.UnmarshalAt(data, path, receiver)
+}
+
+// UnmarshalAt works in the same way as the package-level UnmarshalAt() function, but using the
+// settings from the Config.
+func (c Config) UnmarshalAt(data []byte, p string, receiver interface{}) error {
+	if p == "" {
+		return c.Unmarshal(data, receiver)
+	}
+
+	target, err := receiverTarget(receiver)
+	if err != nil {
+		return err
+	}
+
+	source, err := raw.Parse(data)
+	if err != nil {
+		return fmt.Errorf("error parsing JSON: %w", err)
+	}
+
+	obj, ok := source.Object()
+	if !ok && !source.IsNull() {
+		return fmt.Errorf("cannot find path %q in JSON which is not an object", p)
+	}
+
+	v, found := obj.Fetch(path.Parse(p))
+	return c.unmarshalRoot(target, found, v)
+}
@@ -6,6 +6,7 @@ import (
 	"reflect"
 
 	"code.cloudfoundry.org/jsonry/internal/errorcontext"
+	"code.cloudfoundry.org/jsonry/internal/raw"
 )
 
 type unsupportedType struct {
@@ -77,6 +78,16 @@ func (c conversionError) message(ctx errorcontext.ErrorContext) string {
 	return msg + "into " + ctx.String()
 }
 
+// newSourceConversionError reports that the JSON cannot be unmarshaled into the target, or
+// the error from decoding the JSON when it is not valid
+func newSourceConversionError(source raw.Value) error {
+	v, err := decodeSource(source)
+	if err != nil {
+		return err
+	}
+	return newConversionError(v)
+}
+
 type foreignError struct {
 	msg   string
 	cause error
@@ -149,3 +160,45 @@ func wrapErrorWithKeyContext(err error, keyName string, valueType reflect.Type)
 		}
 	}
 }
+
+type rangeError struct {
+	value interface{}
+}
+
+// newRangeError reports that a number is out of range, where the value is a json.Number,
+// or a string that was coerced into a number in Lenient mode
+func newRangeError(value interface{}) error {
+	return &rangeError{
+		value: value,
+	}
+}
+
+func (r rangeError) Error() string {
+	return r.message(errorcontext.ErrorContext{})
+}
+
+func (r rangeError) message(ctx errorcontext.ErrorContext) string {
+	t := "number"
+	if _, ok := r.value.(string); ok {
+		t = "string"
+	}
+	return fmt.Sprintf(`%s "%s" is out of range for %s`, t, r.value, ctx)
+}
+
+type unionError struct {
+	msg string
+}
+
+func newUnionError(msg string) error {
+	return &unionError{
+		msg: msg,
+	}
+}
+
+func (u unionError) Error() string {
+	return u.message(errorcontext.ErrorContext{})
+}
+
+func (u unionError) message(ctx errorcontext.ErrorContext) string {
+	return fmt.Sprintf("%s at %s", u.msg, ctx)
+}
@@ -52,6 +52,25 @@ func ExampleMarshal_recursive() {
 	// {"a":{"b":[{"c":{"d":[{"e":{"f":"foo"}},{"e":{"f":"bar"}}]}},{"c":{"d":[{"e":{"f":"baz"}},{"e":{"f":"quz"}}]}}]}}
 }
 
+func ExampleConfig_Marshal() {
+	s := struct {
+		A    string
+		B    []int
+		GUID string `jsonry:"relationships.space.data.guid"`
+	}{
+		GUID: "267758c0-985b-11ea-b9ac-48bf6bec2d78",
+	}
+
+	json, err := jsonry.Config{OmitEmpty: true}.Marshal(s)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(string(json))
+	// Output:
+	// {"relationships":{"space":{"data":{"guid":"267758c0-985b-11ea-b9ac-48bf6bec2d78"}}}}
+}
+
 func ExampleUnmarshal() {
 	json := `
     {
@@ -93,3 +112,19 @@ func ExampleUnmarshal() {
 	// GUID: 267758c0-985b-11ea-b9ac-48bf6bec2d78
 	// IDs: [1 2 3 4 5]
 }
+
+func ExampleUnmarshalAs() {
+	type space struct {
+		Name string `jsonry:"data.name"`
+		GUID string `jsonry:"data.guid"`
+	}
+
+	s, err := jsonry.UnmarshalAs[space]([]byte(`{"data":{"name":"foo","guid":"267758c0-985b-11ea-b9ac-48bf6bec2d78"}}`))
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("%+v\n", s)
+	// Output:
+	// {Name:foo GUID:267758c0-985b-11ea-b9ac-48bf6bec2d78}
+}
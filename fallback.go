@@ -0,0 +1,36 @@
+package jsonry
+
+import (
+	"reflect"
+
+	"code.cloudfoundry.org/jsonry/internal/fallback"
+	"code.cloudfoundry.org/jsonry/internal/path"
+	"code.cloudfoundry.org/jsonry/internal/raw"
+)
+
+// The code generated by cmd/jsonrygen uses the default Config for any values that it does not convert itself
+func init() {
+	fallback.Marshal = func(ptr interface{}, field string, p path.Path) (interface{}, error) {
+		v := reflect.ValueOf(ptr).Elem()
+		r, err := (&Config{}).marshal(v, newTagOptions(p))
+		if err != nil {
+			return nil, wrapErrorWithFieldContext(err, field, v.Type())
+		}
+		return r, nil
+	}
+
+	fallback.Unmarshal = func(ptr interface{}, field string, found bool, source raw.Value, p path.Path) error {
+		v := reflect.ValueOf(ptr).Elem()
+		if err := (&Config{}).unmarshal(v, found, source, newTagOptions(p)); err != nil {
+			return wrapErrorWithFieldContext(err, field, v.Type())
+		}
+		return nil
+	}
+
+	fallback.Object = func(source raw.Value) error {
+		if source.IsNull() || source[0] == '{' {
+			return nil
+		}
+		return newSourceConversionError(source)
+	}
+}
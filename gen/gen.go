@@ -0,0 +1,60 @@
+// Package gen supports the MarshalJSON() and UnmarshalJSON() methods written by the jsonrygen
+// command. It is not intended to be used directly, and may change between releases.
+//
+// The generated methods convert string, bool, int*, uint* and float* fields themselves, and
+// other fields are converted by reflection using the default jsonry.Config, so that the JSON is
+// the same as for jsonry.Marshal() and jsonry.Unmarshal().
+package gen
+
+import (
+	"reflect"
+
+	_ "code.cloudfoundry.org/jsonry" // registers the fallback functions
+	"code.cloudfoundry.org/jsonry/internal/path"
+	"code.cloudfoundry.org/jsonry/internal/raw"
+)
+
+// Field is a field of a struct, with its name and tag exactly as declared
+type Field struct {
+	Name string
+	Tag  string
+}
+
+// Plan records the paths of the fields of a struct type. Fields are referred to by their index in the Plan.
+type Plan struct {
+	fields []field
+	paths  *raw.Paths
+}
+
+type field struct {
+	name string
+	path path.Path
+	// spread is the part of the path from a list hint, when there is one
+	spread path.Path
+}
+
+// NewPlan computes the paths of the fields in the same way as jsonry.Marshal() and jsonry.Unmarshal()
+func NewPlan(fields ...Field) *Plan {
+	p := &Plan{fields: make([]field, len(fields))}
+	paths := make([]path.Path, len(fields))
+
+	for i, f := range fields {
+		fp := path.ComputePath(reflect.StructField{Name: f.Name, Tag: reflect.StructTag(f.Tag)})
+		p.fields[i] = field{name: f.Name, path: fp, spread: spreadPath(fp)}
+		paths[i] = fp
+	}
+
+	p.paths = raw.NewPaths(paths)
+	return p
+}
+
+func spreadPath(p path.Path) path.Path {
+	for rest := p; rest.Len() > 1; {
+		segment, stem := rest.Pull()
+		if segment.List {
+			return rest
+		}
+		rest = stem
+	}
+	return path.Path{}
+}
@@ -0,0 +1,143 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"unicode/utf8"
+	"unsafe"
+
+	"code.cloudfoundry.org/jsonry/internal/fallback"
+	"code.cloudfoundry.org/jsonry/internal/raw"
+)
+
+// Reader holds the values for the fields of a struct, read from a JSON object. Once there has been
+// an error, it is returned by Err() and no more fields are set.
+type Reader struct {
+	plan   *Plan
+	values []raw.Value
+	err    error
+}
+
+// Read finds the values for the fields in a JSON object. The JSON may also be null, in which
+// case none of the fields are found.
+func (p *Plan) Read(data []byte) (*Reader, error) {
+	source, err := raw.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing JSON: %w", err)
+	}
+
+	if err := fallback.Object(source); err != nil {
+		return nil, err
+	}
+
+	r := &Reader{plan: p}
+	if source.IsNull() {
+		r.values = make([]raw.Value, len(p.fields))
+	} else {
+		r.values = p.paths.Read(source)
+	}
+
+	return r, nil
+}
+
+// Err returns the first error from setting a field
+func (r *Reader) Err() error {
+	return r.err
+}
+
+// Value sets the field which the pointer points to, in the same way as jsonry.Unmarshal()
+func (r *Reader) Value(i int, ptr interface{}) {
+	if r.err != nil {
+		return
+	}
+
+	f := r.plan.fields[i]
+	v := r.values[i]
+	r.err = fallback.Unmarshal(ptr, f.name, len(v) > 0, v, f.path)
+}
+
+// value returns the JSON for a field when there has been no error and the field was found
+func (r *Reader) value(i int) (raw.Value, bool) {
+	if r.err != nil || len(r.values[i]) == 0 {
+		return nil, false
+	}
+	return r.values[i], true
+}
+
+// The functions below set a field directly when the JSON is of the expected type, and otherwise
+// use Value(), which deals with null and returns the same errors as jsonry.Unmarshal()
+
+func String[T ~string](r *Reader, i int, target *T) {
+	v, ok := r.value(i)
+	if !ok {
+		return
+	}
+
+	if v[0] == '"' && bytes.IndexByte(v, '\\') < 0 && utf8.Valid(v) {
+		*target = T(v[1 : len(v)-1])
+		return
+	}
+
+	r.Value(i, target)
+}
+
+func Bool[T ~bool](r *Reader, i int, target *T) {
+	v, ok := r.value(i)
+	if !ok {
+		return
+	}
+
+	switch string(v) {
+	case "true":
+		*target = true
+	case "false":
+		*target = false
+	default:
+		r.Value(i, target)
+	}
+}
+
+func Int[T ~int | ~int8 | ~int16 | ~int32 | ~int64](r *Reader, i int, target *T) {
+	v, ok := r.value(i)
+	if !ok {
+		return
+	}
+
+	if n, err := strconv.ParseInt(string(v), 10, int(unsafe.Sizeof(*target))*8); err == nil {
+		*target = T(n)
+		return
+	}
+
+	r.Value(i, target)
+}
+
+func Uint[T ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64](r *Reader, i int, target *T) {
+	v, ok := r.value(i)
+	if !ok {
+		return
+	}
+
+	if n, err := strconv.ParseUint(string(v), 10, int(unsafe.Sizeof(*target))*8); err == nil {
+		*target = T(n)
+		return
+	}
+
+	r.Value(i, target)
+}
+
+func Float[T ~float32 | ~float64](r *Reader, i int, target *T) {
+	v, ok := r.value(i)
+	if !ok {
+		return
+	}
+
+	f, err := strconv.ParseFloat(string(v), 64)
+	if err == nil && (unsafe.Sizeof(*target) == 8 || math.Abs(f) <= math.MaxFloat32) {
+		*target = T(f)
+		return
+	}
+
+	r.Value(i, target)
+}
@@ -0,0 +1,154 @@
+package gen
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strconv"
+
+	"code.cloudfoundry.org/jsonry/internal/fallback"
+	"code.cloudfoundry.org/jsonry/internal/tree"
+	"code.cloudfoundry.org/jsonry/internal/write"
+)
+
+// Writer writes the JSON object for a struct. Once there has been an error, it is returned by
+// Bytes() and nothing else is written.
+type Writer struct {
+	plan    *Plan
+	buf     bytes.Buffer
+	written bool
+	objects []object
+	err     error
+}
+
+// object records how to remove a nested object if nothing is written into it, in the same
+// way that jsonry only creates the objects on a path that leads to a value
+type object struct {
+	mark    int
+	written bool
+}
+
+// Writer starts writing a JSON object
+func (p *Plan) Writer() *Writer {
+	w := &Writer{plan: p}
+	w.buf.WriteByte('{')
+	return w
+}
+
+// Key writes the key for the next value in the current object
+func (w *Writer) Key(name string) {
+	if w.written {
+		w.buf.WriteByte(',')
+	}
+	w.written = true
+	write.String(&w.buf, name)
+	w.buf.WriteByte(':')
+}
+
+// Object starts a nested object, which must be finished with End()
+func (w *Writer) Object(name string) {
+	w.objects = append(w.objects, object{mark: w.buf.Len(), written: w.written})
+	w.Key(name)
+	w.buf.WriteByte('{')
+	w.written = false
+}
+
+// End finishes a nested object, removing it when nothing was written into it
+func (w *Writer) End() {
+	o := w.objects[len(w.objects)-1]
+	w.objects = w.objects[:len(w.objects)-1]
+
+	if !w.written {
+		w.buf.Truncate(o.mark)
+		w.written = o.written
+		return
+	}
+
+	w.buf.WriteByte('}')
+}
+
+func (w *Writer) String(s string) {
+	write.String(&w.buf, s)
+}
+
+func (w *Writer) Bool(b bool) {
+	w.buf.WriteString(strconv.FormatBool(b))
+}
+
+func (w *Writer) Int(i int64) {
+	var b [20]byte
+	w.buf.Write(strconv.AppendInt(b[:0], i, 10))
+}
+
+func (w *Writer) Uint(u uint64) {
+	var b [20]byte
+	w.buf.Write(strconv.AppendUint(b[:0], u, 10))
+}
+
+func (w *Writer) Float32(f float32) {
+	w.float(float64(f), 32)
+}
+
+func (w *Writer) Float64(f float64) {
+	w.float(f, 64)
+}
+
+func (w *Writer) float(f float64, bits int) {
+	if !write.Float(&w.buf, f, bits) && w.err == nil {
+		w.err = &json.UnsupportedValueError{Value: reflect.ValueOf(f), Str: strconv.FormatFloat(f, 'g', -1, bits)}
+	}
+}
+
+// Value writes the value of the field which the pointer points to, in the same way as jsonry.Marshal()
+func (w *Writer) Value(i int, ptr interface{}) {
+	if w.err != nil {
+		return
+	}
+
+	f := w.plan.fields[i]
+	r, err := fallback.Marshal(ptr, f.name, f.path)
+	if err != nil {
+		w.err = err
+		return
+	}
+
+	w.writeInterface(r)
+}
+
+// Spread writes the value of a field that has a list hint in its path. The list is written at the
+// key of the list hint, with the value of the field spread across the elements in the same way as
+// jsonry.Marshal() would.
+func (w *Writer) Spread(i int, ptr interface{}) {
+	if w.err != nil {
+		return
+	}
+
+	f := w.plan.fields[i]
+	r, err := fallback.Marshal(ptr, f.name, f.path)
+	if err != nil {
+		w.err = err
+		return
+	}
+
+	segment, _ := f.spread.Pull()
+	w.writeInterface(make(tree.Tree).Attach(f.spread, r)[segment.Name])
+}
+
+func (w *Writer) writeInterface(v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		w.err = err
+		return
+	}
+	w.buf.Write(b)
+}
+
+// Bytes finishes the JSON object, and returns it
+func (w *Writer) Bytes() ([]byte, error) {
+	if w.err != nil {
+		return nil, w.err
+	}
+
+	w.buf.WriteByte('}')
+	return w.buf.Bytes(), nil
+}
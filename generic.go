@@ -0,0 +1,28 @@
+package jsonry
+
+import "reflect"
+
+// UnmarshalAs parses the specified JSON into a new value of type T, which is typically a struct or a pointer
+// to a struct. It works in the same way as Unmarshal(), without the need to pass in a receiver. For example:
+//
+//	app, err := jsonry.UnmarshalAs[App](data)
+//	apps, err := jsonry.UnmarshalAs[[]App](data)
+func UnmarshalAs[T any](data []byte) (T, error) {
+	return UnmarshalAsWithConfig[T](Config{}, data)
+}
+
+// UnmarshalAsWithConfig works in the same way as UnmarshalAs(), but using the settings from the Config.
+func UnmarshalAsWithConfig[T any](c Config, data []byte) (T, error) {
+	var result T
+
+	t := reflect.TypeOf(&result).Elem()
+	if t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct {
+		v := reflect.New(t.Elem())
+		if err := c.Unmarshal(data, v.Interface()); err != nil {
+			return result, err
+		}
+		return v.Interface().(T), nil
+	}
+
+	return result, c.Unmarshal(data, &result)
+}
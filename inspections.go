@@ -1,10 +1,16 @@
 package jsonry
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 )
 
+var (
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+	presenceType   = reflect.TypeOf((*Presence)(nil)).Elem()
+)
+
 func public(field reflect.StructField) bool {
 	return field.PkgPath == ""
 }
@@ -21,6 +27,10 @@ func basicType(k reflect.Kind) bool {
 	}
 }
 
+func complexType(k reflect.Kind) bool {
+	return k == reflect.Complex64 || k == reflect.Complex128
+}
+
 func checkForError(v reflect.Value) error {
 	if v.IsNil() {
 		return nil
@@ -35,3 +45,13 @@ func checkForError(v reflect.Value) error {
 	r := v.MethodByName("Error").Call(nil)
 	return fmt.Errorf("%s", r[0])
 }
+
+// checkJSON validates JSON, only doing the extra work to get a detailed error when it is invalid
+func checkJSON(data []byte) error {
+	if json.Valid(data) {
+		return nil
+	}
+
+	var r interface{}
+	return json.Unmarshal(data, &r)
+}
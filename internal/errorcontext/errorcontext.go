@@ -35,7 +35,7 @@ func (ctx ErrorContext) String() string {
 	case 1:
 		return ctx.leaf().String()
 	default:
-		return fmt.Sprintf("%s path %s", ctx.leaf(), ctx.path())
+		return fmt.Sprintf("%s path %s", ctx.leaf(), ctx.Path())
 	}
 }
 
@@ -43,7 +43,8 @@ func (ctx ErrorContext) leaf() segment {
 	return ctx[len(ctx)-1]
 }
 
-func (ctx ErrorContext) path() string {
+// Path is the path of fields, indices and keys from the root, for example Foo.Bar[3]["k"]
+func (ctx ErrorContext) Path() string {
 	var path string
 	for _, s := range ctx {
 		switch s.sort {
@@ -96,4 +96,18 @@ var _ = Describe("ErrorContext", func() {
 			Expect(ctx.String()).To(Equal(`field "Baz" (type "int") path Foo[5][3]["foo"].Bar["bar"][4].Baz`))
 		})
 	})
+
+	Describe("Path", func() {
+		It("is empty at the root", func() {
+			Expect(errorcontext.ErrorContext{}.Path()).To(BeEmpty())
+		})
+
+		It("reports the path from the root", func() {
+			ctx := errorcontext.ErrorContext{}.
+				WithIndex(4, reflect.TypeOf(42)).
+				WithKey("bar", reflect.TypeOf(42)).
+				WithField("Foo", reflect.TypeOf(""))
+			Expect(ctx.Path()).To(Equal(`Foo["bar"][4]`))
+		})
+	})
 })
@@ -0,0 +1,21 @@
+// Package fallback gives the gen package access to the reflection based implementation in the
+// jsonry package, which registers the functions when it is initialized. Generated code uses them
+// for values that it does not convert itself, so that the results are always the same.
+package fallback
+
+import (
+	"code.cloudfoundry.org/jsonry/internal/path"
+	"code.cloudfoundry.org/jsonry/internal/raw"
+)
+
+var (
+	// Marshal converts the struct field that the pointer points to into a value which can be
+	// passed to json.Marshal(). Errors have the context of the field.
+	Marshal func(ptr interface{}, field string, p path.Path) (interface{}, error)
+
+	// Unmarshal sets the struct field that the pointer points to. Errors have the context of the field.
+	Unmarshal func(ptr interface{}, field string, found bool, source raw.Value, p path.Path) error
+
+	// Object returns the same error as unmarshaling a struct when the value is not a JSON object or null
+	Object func(source raw.Value) error
+)
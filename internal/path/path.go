@@ -7,10 +7,15 @@ import (
 )
 
 const (
-	omitEmptyToken  string = ",omitempty"
+	omitEmptyToken  string = "omitempty"
 	omitAlwaysToken string = "-"
+	defaultToken    string = "default="
+	jsonTag         string = "json"
+	defaultTag      string = "default"
 )
 
+var defaultTags = []string{jsonTag, "jsonry"}
+
 type Segment struct {
 	Name string
 	List bool
@@ -18,8 +23,14 @@ type Segment struct {
 
 type Path struct {
 	segments   []Segment
+	options    []string
 	OmitEmpty  bool
 	OmitAlways bool
+
+	// Default is the default value of the field, from a "default" tag or a ",default=" option,
+	// and HasDefault reports whether there is one
+	Default    string
+	HasDefault bool
 }
 
 func (p Path) Len() int {
@@ -33,6 +44,27 @@ func (p Path) Pull() (Segment, Path) {
 	}
 }
 
+// Split returns the path of the object that contains the last segment, and the last segment
+func (p Path) Split() (Path, Segment) {
+	last := len(p.segments) - 1
+	return Path{segments: p.segments[:last], OmitEmpty: p.OmitEmpty}, p.segments[last]
+}
+
+// Option reports whether the named option was specified in the tag, for example "omitempty"
+func (p Path) Option(name string) bool {
+	for _, o := range p.options {
+		if o == name {
+			return true
+		}
+	}
+	return false
+}
+
+// TagOptions returns the options that were specified in the tag
+func (p Path) TagOptions() []string {
+	return append([]string(nil), p.options...)
+}
+
 func (p Path) String() string {
 	var parts []string
 	for _, s := range p.segments {
@@ -45,17 +77,47 @@ func (p Path) String() string {
 	return strings.Join(parts, ".")
 }
 
+// Options control how a Path is computed from a struct field
+type Options struct {
+	// Tags are the struct tag keys that are read, in priority order. A "json" tag is a single
+	// name, and any other tag may be a nested path. Defaults to "json" then "jsonry".
+	Tags []string
+
+	// Naming converts the field name into a JSON name when no name is specified in a tag
+	Naming func(string) string
+}
+
+// Parse reads a JSONry path, for example "relationships.space.data", which may contain list hints
+func Parse(name string) Path {
+	return Path{segments: parseSegments(name)}
+}
+
 func ComputePath(field reflect.StructField) Path {
+	return Options{}.ComputePath(field)
+}
+
+func (o Options) ComputePath(field reflect.StructField) Path {
 	var segments []Segment
 	name := field.Name
-	omitempty := false
+	if o.Naming != nil {
+		name = o.Naming(field.Name)
+	}
+	var options []string
 	omitalways := false
 
-	if tag := field.Tag.Get("json"); tag != "" {
-		name, omitempty, omitalways = parseTag(tag, field.Name)
-	} else if tag := field.Tag.Get("jsonry"); tag != "" {
-		name, omitempty, omitalways = parseTag(tag, field.Name)
-		segments = parseSegments(name)
+	tags := o.Tags
+	if len(tags) == 0 {
+		tags = defaultTags
+	}
+
+	for _, key := range tags {
+		if tag := field.Tag.Get(key); tag != "" {
+			name, options, omitalways = parseTag(tag, name)
+			if key != jsonTag {
+				segments = parseSegments(name)
+			}
+			break
+		}
 	}
 
 	if len(segments) == 0 {
@@ -65,31 +127,43 @@ func ComputePath(field reflect.StructField) Path {
 		})
 	}
 
-	return Path{
-		OmitEmpty:  omitempty,
+	p := Path{
 		OmitAlways: omitalways,
 		segments:   segments,
+		options:    options,
 	}
+	p.OmitEmpty = p.Option(omitEmptyToken)
+	p.Default, p.HasDefault = defaultValue(field, options)
+	return p
 }
 
-func parseTag(tag, defaultName string) (name string, omitempty, omitalways bool) {
+// defaultValue reads the default from the "default" tag, which may contain any characters,
+// or otherwise from a ",default=" option, which cannot contain a comma
+func defaultValue(field reflect.StructField, options []string) (string, bool) {
+	if d, ok := field.Tag.Lookup(defaultTag); ok {
+		return d, true
+	}
+
+	for _, o := range options {
+		if strings.HasPrefix(o, defaultToken) {
+			return o[len(defaultToken):], true
+		}
+	}
+	return "", false
+}
+
+func parseTag(tag, defaultName string) (name string, options []string, omitalways bool) {
 	if tag == omitAlwaysToken {
-		return defaultName, false, true
+		return defaultName, nil, true
 	}
 
-	commaIndex := strings.IndexByte(tag, ',')
-	switch commaIndex {
-	case -1:
-		name = tag
-	case 0:
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	if name == "" {
 		name = defaultName
-		omitempty = tag == omitEmptyToken
-	default:
-		name = tag[0:commaIndex]
-		omitempty = tag[commaIndex:] == omitEmptyToken
 	}
 
-	return
+	return name, parts[1:], false
 }
 
 func parseSegments(name string) (s []Segment) {
@@ -2,6 +2,7 @@ package path_test
 
 import (
 	"reflect"
+	"strings"
 
 	"code.cloudfoundry.org/jsonry/internal/path"
 	. "github.com/onsi/ginkgo/v2"
@@ -40,6 +41,18 @@ var _ = Describe("Path", func() {
 		Expect(p.Len()).To(Equal(2))
 	})
 
+	It("implements Split()", func() {
+		p := path.ComputePath(reflect.StructField{Tag: `jsonry:"foo.bar[].baz"`})
+
+		p, s := p.Split()
+		Expect(s).To(Equal(path.Segment{Name: "baz", List: false}))
+		Expect(p.String()).To(Equal("foo.bar[]"))
+
+		p, s = path.Parse("foo").Split()
+		Expect(s).To(Equal(path.Segment{Name: "foo", List: false}))
+		Expect(p.Len()).To(BeZero())
+	})
+
 	Context("omitempty", func() {
 		It("picks it up from a JSON tag", func() {
 			p := path.ComputePath(reflect.StructField{Tag: `json:",omitempty"`})
@@ -52,6 +65,48 @@ var _ = Describe("Path", func() {
 		})
 	})
 
+	Context("options", func() {
+		It("reads options from a JSON tag", func() {
+			p := path.ComputePath(reflect.StructField{Tag: `json:"foo,hex,omitempty"`})
+			Expect(p.String()).To(Equal("foo"))
+			Expect(p.Option("hex")).To(BeTrue())
+			Expect(p.OmitEmpty).To(BeTrue())
+			Expect(p.TagOptions()).To(Equal([]string{"hex", "omitempty"}))
+		})
+
+		It("reads options from a JSONry tag", func() {
+			p := path.ComputePath(reflect.StructField{Name: "Foo", Tag: `jsonry:",base64url"`})
+			Expect(p.String()).To(Equal("Foo"))
+			Expect(p.Option("base64url")).To(BeTrue())
+			Expect(p.Option("hex")).To(BeFalse())
+			Expect(p.OmitEmpty).To(BeFalse())
+		})
+	})
+
+	Context("default", func() {
+		It("reads it from a tag option", func() {
+			p := path.ComputePath(reflect.StructField{Tag: `jsonry:"lifecycle.type,default=buildpack,omitempty"`})
+			Expect(p.Default).To(Equal("buildpack"))
+			Expect(p.HasDefault).To(BeTrue())
+			Expect(p.OmitEmpty).To(BeTrue())
+		})
+
+		It("reads it from a default tag, which takes priority", func() {
+			p := path.ComputePath(reflect.StructField{Name: "Foo", Tag: `jsonry:",default=a" default:"b,c"`})
+			Expect(p.Default).To(Equal("b,c"))
+			Expect(p.HasDefault).To(BeTrue())
+
+			p = path.ComputePath(reflect.StructField{Name: "Foo", Tag: `default:""`})
+			Expect(p.Default).To(BeEmpty())
+			Expect(p.HasDefault).To(BeTrue())
+		})
+
+		It("does not have a default otherwise", func() {
+			p := path.ComputePath(reflect.StructField{Tag: `jsonry:"foo,omitempty"`})
+			Expect(p.HasDefault).To(BeFalse())
+		})
+	})
+
 	Context("always omit", func() {
 		It("picks it up from a JSON tag", func() {
 			p := path.ComputePath(reflect.StructField{Tag: `json:"-"`})
@@ -73,4 +128,61 @@ var _ = Describe("Path", func() {
 			Expect(p.OmitAlways).To(BeFalse())
 		})
 	})
+
+	Context("tags", func() {
+		field := reflect.StructField{Name: "Foo", Tag: `json:"foo" jsonry:"bar.baz" cf:"quz.foo"`}
+
+		It("gives the JSON tag priority by default", func() {
+			p := path.ComputePath(field)
+			Expect(p.String()).To(Equal("foo"))
+		})
+
+		It("reads the tags in the specified order", func() {
+			p := path.Options{Tags: []string{"jsonry", "json"}}.ComputePath(field)
+			Expect(p.String()).To(Equal("bar.baz"))
+			Expect(p.Len()).To(Equal(2))
+		})
+
+		It("can read a custom tag", func() {
+			p := path.Options{Tags: []string{"cf"}}.ComputePath(field)
+			Expect(p.String()).To(Equal("quz.foo"))
+			Expect(p.Len()).To(Equal(2))
+		})
+
+		It("falls back to the next tag", func() {
+			p := path.Options{Tags: []string{"other", "jsonry"}}.ComputePath(field)
+			Expect(p.String()).To(Equal("bar.baz"))
+		})
+
+		It("falls back to the field name", func() {
+			p := path.Options{Tags: []string{"other"}}.ComputePath(field)
+			Expect(p.String()).To(Equal("Foo"))
+		})
+
+		It("does not nest a JSON tag", func() {
+			p := path.Options{Tags: []string{"json"}}.ComputePath(reflect.StructField{Tag: `json:"foo.bar"`})
+			Expect(p.String()).To(Equal("foo.bar"))
+			Expect(p.Len()).To(Equal(1))
+		})
+	})
+
+	Context("naming", func() {
+		naming := path.Options{Naming: strings.ToLower}
+
+		It("names a field without a tag", func() {
+			p := naming.ComputePath(reflect.StructField{Name: "Foo"})
+			Expect(p.String()).To(Equal("foo"))
+		})
+
+		It("names a field with a tag that has no name", func() {
+			p := naming.ComputePath(reflect.StructField{Name: "Foo", Tag: `jsonry:",omitempty"`})
+			Expect(p.String()).To(Equal("foo"))
+			Expect(p.OmitEmpty).To(BeTrue())
+		})
+
+		It("does not rename a field with a named tag", func() {
+			p := naming.ComputePath(reflect.StructField{Name: "Foo", Tag: `jsonry:"Bar.Baz"`})
+			Expect(p.String()).To(Equal("Bar.Baz"))
+		})
+	})
 })
@@ -0,0 +1,168 @@
+package raw
+
+import "code.cloudfoundry.org/jsonry/internal/path"
+
+// Paths reads the values at a number of paths from a JSON object in one pass. The paths form a
+// prefix trie, so that the object is walked once, and any values which are not read are skipped
+// without being parsed. Where a value part way along a path is an array, then the value is found
+// in the same way as Object.Fetch() would.
+//
+// The walk scans the bytes of the input rather than using json.Decoder.Token(), because Token()
+// allocates for every key and value including those that are skipped, and cannot give the bytes
+// of a value exactly as they appear in the input, which json.RawMessage and json.Unmarshaler need.
+type Paths struct {
+	count    int
+	branches int
+	members  []*node
+}
+
+// node is a key in the JSON object. When the key is at the end of a path then leaf is set,
+// and when the key is part of a longer path then there are members.
+type node struct {
+	name    string
+	leaf    int
+	branch  int
+	members []*node
+	spread  []spreadPath
+}
+
+type spreadPath struct {
+	index int
+	path  path.Path
+}
+
+func NewPaths(paths []path.Path) *Paths {
+	p := &Paths{count: len(paths)}
+	for i := range paths {
+		p.add(paths[i], i)
+	}
+	return p
+}
+
+func (p *Paths) add(fp path.Path, index int) {
+	members := &p.members
+	rest := fp
+
+	for {
+		segment, stem := rest.Pull()
+
+		var n *node
+		for _, m := range *members {
+			if m.name == segment.Name {
+				n = m
+			}
+		}
+		if n == nil {
+			n = &node{name: segment.Name, leaf: -1, branch: -1}
+			*members = append(*members, n)
+		}
+
+		if stem.Len() == 0 {
+			n.leaf = index
+			return
+		}
+
+		if n.branch < 0 {
+			n.branch = p.branches
+			p.branches++
+		}
+		n.spread = append(n.spread, spreadPath{index: index, path: rest})
+
+		members = &n.members
+		rest = stem
+	}
+}
+
+// Read finds the value for each path in the object, in the same order as the paths. A value
+// is empty when the path is not in the object.
+func (p *Paths) Read(source Value) []Value {
+	values := make([]Value, p.count+p.branches)
+	p.readMembers(values, p.members, source, nil)
+	return values[:p.count]
+}
+
+// ReadRest works in the same way as Read, and also returns the members of the object that
+// are not read by any path. Where a path goes through an object, the members of that object
+// that are not read are returned within an object with the same key, for example {"a":{"c":3}}
+// when there is a path "a.b". Where a path goes through an array, the members of the objects
+// in the array are not returned.
+func (p *Paths) ReadRest(source Value) ([]Value, Object, error) {
+	values := make([]Value, p.count+p.branches)
+	rest := make(Object)
+	if err := p.readMembers(values, p.members, source, rest); err != nil {
+		return nil, nil, err
+	}
+	return values[:p.count], rest, nil
+}
+
+func (p *Paths) readMembers(values []Value, members []*node, source Value, rest Object) error {
+	var err error
+	source.Members(func(key, value Value) {
+		if p.store(values, members, key, value) || rest == nil || err != nil {
+			return
+		}
+
+		var k string
+		if k, err = decodeString(key); err == nil {
+			rest[k] = value
+		}
+	})
+
+	if err != nil {
+		return err
+	}
+	return p.readBranches(values, members, rest)
+}
+
+// store records the value when the key is in the members. Values for branches are
+// stored after the values for the paths.
+func (p *Paths) store(values []Value, members []*node, key, value Value) bool {
+	for _, m := range members {
+		if KeyEquals(key, m.name) {
+			if m.leaf >= 0 {
+				values[m.leaf] = value
+			}
+			if m.branch >= 0 {
+				values[p.count+m.branch] = value
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// readBranches reads the values for the paths that go through each member. When the rest is being
+// collected, and a member is only part of longer paths, then the members of its object that are not
+// read are collected too, and a value that is not an object or array is not read by any path.
+func (p *Paths) readBranches(values []Value, members []*node, rest Object) error {
+	for _, m := range members {
+		if m.branch < 0 {
+			continue
+		}
+
+		var nested Object
+		if rest != nil && m.leaf < 0 {
+			nested = make(Object)
+		}
+
+		v := values[p.count+m.branch]
+		switch {
+		case len(v) == 0:
+		case v[0] == '{':
+			if err := p.readMembers(values, m.members, v, nested); err != nil {
+				return err
+			}
+			if len(nested) > 0 {
+				rest[m.name] = nested.Value()
+			}
+		case v[0] == '[':
+			o := Object{m.name: v}
+			for _, s := range m.spread {
+				values[s.index], _ = o.Fetch(s.path)
+			}
+		case nested != nil:
+			rest[m.name] = v
+		}
+	}
+	return nil
+}
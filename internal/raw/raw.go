@@ -0,0 +1,316 @@
+// Package raw represents a JSON document that is only parsed as far as is needed, so that
+// any value in the document is available exactly as it appeared in the input
+package raw
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+
+	"code.cloudfoundry.org/jsonry/internal/path"
+)
+
+var null = Value("null")
+
+// Value is a single valid JSON value, with no leading or trailing whitespace.
+// The zero value represents a value that is not present.
+type Value []byte
+
+// Object is a JSON object which has been parsed one level deep
+type Object map[string]Value
+
+// Parse reads the first JSON value from the data, checking that it is valid
+func Parse(data []byte) (Value, error) {
+	if json.Valid(data) {
+		start := skipSpace(data, 0)
+		return Value(data[start:valueEnd(data, start)]), nil
+	}
+
+	var m json.RawMessage
+	d := json.NewDecoder(bytes.NewReader(data))
+	if err := d.Decode(&m); err != nil {
+		return nil, err
+	}
+	return Value(m), nil
+}
+
+func (v Value) IsNull() bool {
+	return bytes.Equal(v, null)
+}
+
+// Object parses the value one level deep if it is a JSON object. It reports false if a key
+// cannot be decoded.
+func (v Value) Object() (Object, bool) {
+	if len(v) == 0 || v[0] != '{' {
+		return nil, false
+	}
+
+	o := make(Object)
+	ok := true
+	v.Members(func(key, value Value) {
+		k, err := decodeString(key)
+		if err != nil {
+			ok = false
+		}
+		o[k] = value
+	})
+
+	if !ok {
+		return nil, false
+	}
+	return o, true
+}
+
+// Members calls the function with the key and value of each member of the object in the
+// order that they appear, without decoding the keys. It reports false if the value is not
+// a JSON object.
+func (v Value) Members(f func(key, value Value)) bool {
+	if len(v) == 0 || v[0] != '{' {
+		return false
+	}
+
+	i := skipSpace(v, 1)
+	for v[i] != '}' {
+		keyEnd := stringEnd(v, i)
+		key := v[i:keyEnd]
+
+		start := skipSpace(v, skipSpace(v, keyEnd)+1)
+		end := valueEnd(v, start)
+		f(key, v[start:end])
+
+		i = skipSpace(v, end)
+		if v[i] == ',' {
+			i = skipSpace(v, i+1)
+		}
+	}
+
+	return true
+}
+
+// KeyEquals reports whether a key from Members() is the same as the name
+func KeyEquals(key Value, name string) bool {
+	if bytes.IndexByte(key, '\\') < 0 {
+		return string(key[1:len(key)-1]) == name
+	}
+
+	k, err := decodeString(key)
+	return err == nil && k == name
+}
+
+// Key decodes a key from Members()
+func Key(key Value) (string, error) {
+	return decodeString(key)
+}
+
+// Array parses the value one level deep if it is a JSON array
+func (v Value) Array() ([]Value, bool) {
+	if len(v) == 0 || v[0] != '[' {
+		return nil, false
+	}
+
+	a := make([]Value, 0, v.count())
+	i := skipSpace(v, 1)
+	for v[i] != ']' {
+		end := valueEnd(v, i)
+		a = append(a, v[i:end])
+
+		i = skipSpace(v, end)
+		if v[i] == ',' {
+			i = skipSpace(v, i+1)
+		}
+	}
+
+	return a, true
+}
+
+// count finds the number of elements in an array, so that they can be stored without reallocating
+func (v Value) count() int {
+	n := 0
+	for i := skipSpace(v, 1); v[i] != ']'; n++ {
+		i = skipSpace(v, valueEnd(v, i))
+		if v[i] == ',' {
+			i = skipSpace(v, i+1)
+		}
+	}
+	return n
+}
+
+// Interface decodes the value into a Go value in the same way as encoding/json,
+// except that numbers are decoded as json.Number. A value that is not present
+// decodes as nil.
+func (v Value) Interface() (interface{}, error) {
+	if len(v) == 0 {
+		return nil, nil
+	}
+
+	switch v[0] {
+	case 'n':
+		return nil, nil
+	case 't':
+		return true, nil
+	case 'f':
+		return false, nil
+	case '"':
+		return decodeString(v)
+	case '{', '[':
+		var r interface{}
+		d := json.NewDecoder(bytes.NewReader(v))
+		d.UseNumber()
+		if err := d.Decode(&r); err != nil {
+			return nil, err
+		}
+		return r, nil
+	default:
+		return json.Number(v), nil
+	}
+}
+
+// Fetch finds the value at the path in the same way as tree.Tree.Fetch() would
+// for the decoded document
+func (o Object) Fetch(p path.Path) (Value, bool) {
+	switch p.Len() {
+	case 0:
+		panic("empty path")
+	case 1:
+		leaf, _ := p.Pull()
+		v, ok := o[leaf.Name]
+		return v, ok
+	default:
+		branch, stem := p.Pull()
+		v, ok := o[branch.Name]
+		if !ok {
+			return nil, false
+		}
+
+		if obj, ok := v.Object(); ok {
+			return obj.Fetch(stem)
+		}
+
+		if arr, ok := v.Array(); ok {
+			return join(unspread(arr, stem)), true
+		}
+
+		return nil, false
+	}
+}
+
+// Value encodes the object as a JSON object, with the keys in sorted order.
+// The values are written exactly as they are.
+func (o Object) Value() Value {
+	keys := make([]string, 0, len(o))
+	for k := range o {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(k)
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(o[k])
+	}
+	b.WriteByte('}')
+	return b.Bytes()
+}
+
+func unspread(v []Value, stem path.Path) []Value {
+	l := make([]Value, 0, len(v))
+	for i := range v {
+		if obj, ok := v[i].Object(); ok {
+			if r, ok := obj.Fetch(stem); ok {
+				l = append(l, r)
+			} else {
+				l = append(l, null)
+			}
+		} else if arr, ok := v[i].Array(); ok {
+			l = append(l, unspread(arr, stem)...)
+		} else {
+			l = append(l, v[i])
+		}
+	}
+	return l
+}
+
+func join(l []Value) Value {
+	var b bytes.Buffer
+	b.WriteByte('[')
+	for i := range l {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.Write(l[i])
+	}
+	b.WriteByte(']')
+	return b.Bytes()
+}
+
+func decodeString(s []byte) (string, error) {
+	if len(s) >= 2 && s[len(s)-1] == '"' && bytes.IndexByte(s, '\\') < 0 {
+		return string(s[1 : len(s)-1]), nil
+	}
+
+	var r string
+	if err := json.Unmarshal(s, &r); err != nil {
+		return "", err
+	}
+	return r, nil
+}
+
+// The functions below scan JSON which is known to be valid, so do not check for errors
+
+func skipSpace(v []byte, i int) int {
+	for i < len(v) {
+		switch v[i] {
+		case ' ', '\t', '\n', '\r':
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+func stringEnd(v []byte, i int) int {
+	for i = i + 1; v[i] != '"'; i++ {
+		if v[i] == '\\' {
+			i++
+		}
+	}
+	return i + 1
+}
+
+func valueEnd(v []byte, i int) int {
+	switch v[i] {
+	case '"':
+		return stringEnd(v, i)
+	case '{', '[':
+		depth := 0
+		for ; i < len(v); i++ {
+			switch v[i] {
+			case '"':
+				i = stringEnd(v, i) - 1
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return i + 1
+				}
+			}
+		}
+		return i
+	default:
+		for ; i < len(v); i++ {
+			switch v[i] {
+			case ',', '}', ']', ' ', '\t', '\n', '\r':
+				return i
+			}
+		}
+		return i
+	}
+}
@@ -0,0 +1,267 @@
+package raw_test
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"code.cloudfoundry.org/jsonry/internal/path"
+	"code.cloudfoundry.org/jsonry/internal/raw"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Raw", func() {
+	parse := func(s string) raw.Value {
+		v, err := raw.Parse([]byte(s))
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		return v
+	}
+
+	object := func(s string) raw.Object {
+		o, ok := parse(s).Object()
+		ExpectWithOffset(1, ok).To(BeTrue())
+		return o
+	}
+
+	jsonryPath := func(s string) path.Path {
+		return path.ComputePath(reflect.StructField{Tag: reflect.StructTag(`jsonry:"` + s + `"`)})
+	}
+
+	Describe("Parse", func() {
+		It("trims surrounding whitespace", func() {
+			Expect(parse(" \n{ \"a\" : 1 }\t")).To(Equal(raw.Value(`{ "a" : 1 }`)))
+		})
+
+		It("reads the first value", func() {
+			Expect(parse(`{"a":1} {"b":2}`)).To(Equal(raw.Value(`{"a":1}`)))
+		})
+
+		It("rejects invalid JSON", func() {
+			_, err := raw.Parse([]byte(`{"a":`))
+			Expect(err).To(MatchError("unexpected EOF"))
+		})
+	})
+
+	Describe("Object", func() {
+		It("parses one level deep", func() {
+			o := object(`{ "a" : { "b": [1, 2] } , "c":"d" ,"eé":null,"f":{}}`)
+			Expect(o).To(Equal(raw.Object{
+				"a":  raw.Value(`{ "b": [1, 2] }`),
+				"c":  raw.Value(`"d"`),
+				"eé": raw.Value(`null`),
+				"f":  raw.Value(`{}`),
+			}))
+		})
+
+		It("parses an empty object", func() {
+			Expect(object(` { } `)).To(BeEmpty())
+		})
+
+		It("handles strings containing structural characters", func() {
+			o := object(`{"a":"}\"]{,", "b":["]",{"}":"\\"}]}`)
+			Expect(o).To(Equal(raw.Object{
+				"a": raw.Value(`"}\"]{,"`),
+				"b": raw.Value(`["]",{"}":"\\"}]`),
+			}))
+		})
+
+		It("is not ok for other values", func() {
+			for _, s := range []string{`[]`, `"a"`, `1`, `null`, `true`} {
+				_, ok := parse(s).Object()
+				Expect(ok).To(BeFalse())
+			}
+		})
+	})
+
+	Describe("Members", func() {
+		It("visits the members in order without decoding the keys", func() {
+			var keys, values []string
+			ok := parse(`{ "a" : 1, "b\u00e9": {"c":[]} , "a":"x"}`).Members(func(key, value raw.Value) {
+				keys = append(keys, string(key))
+				values = append(values, string(value))
+			})
+			Expect(ok).To(BeTrue())
+			Expect(keys).To(Equal([]string{`"a"`, `"b\u00e9"`, `"a"`}))
+			Expect(values).To(Equal([]string{`1`, `{"c":[]}`, `"x"`}))
+		})
+
+		It("is not ok for other values", func() {
+			Expect(parse(`[1]`).Members(func(raw.Value, raw.Value) {})).To(BeFalse())
+		})
+
+		It("can compare and decode keys", func() {
+			Expect(raw.KeyEquals(raw.Value(`"abc"`), "abc")).To(BeTrue())
+			Expect(raw.KeyEquals(raw.Value(`"abc"`), "ab")).To(BeFalse())
+			Expect(raw.KeyEquals(raw.Value(`"b\u00e9"`), "bé")).To(BeTrue())
+			Expect(raw.Key(raw.Value(`"b\u00e9"`))).To(Equal("bé"))
+
+			_, err := raw.Key(raw.Value(`"b\x"`))
+			Expect(err).To(HaveOccurred())
+			Expect(raw.KeyEquals(raw.Value(`"b\x"`), `b\x`)).To(BeFalse())
+		})
+	})
+
+	Describe("Object Value", func() {
+		It("encodes the object with sorted keys and values as they are", func() {
+			o := raw.Object{"b": raw.Value(`[1, 2.0]`), "a\"": raw.Value(`{ "z":1 }`)}
+			Expect(string(o.Value())).To(Equal(`{"a\"":{ "z":1 },"b":[1, 2.0]}`))
+		})
+
+		It("encodes an empty object", func() {
+			Expect(string(raw.Object{}.Value())).To(Equal(`{}`))
+		})
+	})
+
+	Describe("Array", func() {
+		It("parses one level deep", func() {
+			a, ok := parse(`[ 1, "two" ,[3], {"four":4},true,null ]`).Array()
+			Expect(ok).To(BeTrue())
+			Expect(a).To(Equal([]raw.Value{
+				raw.Value(`1`),
+				raw.Value(`"two"`),
+				raw.Value(`[3]`),
+				raw.Value(`{"four":4}`),
+				raw.Value(`true`),
+				raw.Value(`null`),
+			}))
+		})
+
+		It("parses an empty array", func() {
+			a, ok := parse(`[ ]`).Array()
+			Expect(ok).To(BeTrue())
+			Expect(a).To(BeEmpty())
+		})
+
+		It("is not ok for other values", func() {
+			_, ok := parse(`{}`).Array()
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("Interface", func() {
+		It("decodes values", func() {
+			Expect(parse(`null`).Interface()).To(BeNil())
+			Expect(parse(`true`).Interface()).To(BeTrue())
+			Expect(parse(`false`).Interface()).To(BeFalse())
+			Expect(parse(`"a\nb"`).Interface()).To(Equal("a\nb"))
+			Expect(parse(`-1.50e3`).Interface()).To(Equal(json.Number("-1.50e3")))
+			Expect(parse(`[1,{"a":"b"}]`).Interface()).To(Equal([]interface{}{json.Number("1"), map[string]interface{}{"a": "b"}}))
+		})
+
+		It("decodes a missing value as nil", func() {
+			Expect(raw.Value(nil).Interface()).To(BeNil())
+		})
+
+		It("returns an error for malformed JSON", func() {
+			_, err := raw.Value(`"a\`).Interface()
+			Expect(err).To(HaveOccurred())
+
+			_, err = raw.Value(`[1,`).Interface()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("IsNull", func() {
+		It("reports null", func() {
+			Expect(parse(`null`).IsNull()).To(BeTrue())
+			Expect(parse(`"null"`).IsNull()).To(BeFalse())
+			Expect(raw.Value(nil).IsNull()).To(BeFalse())
+		})
+	})
+
+	Describe("Fetch", func() {
+		It("can fetch a basic value", func() {
+			v, ok := object(`{"a":{"b":{"c":{"d":{"e":"hello"}}}}}`).Fetch(jsonryPath("a.b.c.d.e"))
+			Expect(ok).To(BeTrue())
+			Expect(v).To(Equal(raw.Value(`"hello"`)))
+		})
+
+		It("says not ok when not there", func() {
+			v, ok := object(`{"a":{"b":{"c":{"d":{"e":"hello"}}}}}`).Fetch(jsonryPath("a.b.not_there.d.e"))
+			Expect(ok).To(BeFalse())
+			Expect(v).To(BeNil())
+		})
+
+		It("says not ok when a branch is not an object or list", func() {
+			_, ok := object(`{"a":{"b":"c"}}`).Fetch(jsonryPath("a.b.c"))
+			Expect(ok).To(BeFalse())
+		})
+
+		It("can fetch a null", func() {
+			v, ok := object(`{"a":{"b":{"c":{"d":{"e":null}}}}}`).Fetch(jsonryPath("a.b.c.d.e"))
+			Expect(ok).To(BeTrue())
+			Expect(v.IsNull()).To(BeTrue())
+		})
+
+		It("can fetch an object exactly as it appears", func() {
+			v, ok := object(`{"a":{"b":{"c": {"d" : {"e":"hello"}} }}}`).Fetch(jsonryPath("a.b.c"))
+			Expect(ok).To(BeTrue())
+			Expect(v).To(Equal(raw.Value(`{"d" : {"e":"hello"}}`)))
+		})
+
+		It("can fetch a list at the leaf", func() {
+			v, ok := object(`{"a":{"b":{"c":{"d":{"e":["h","e","l","l","o"]}}}}}`).Fetch(jsonryPath("a.b.c.d.e"))
+			Expect(ok).To(BeTrue())
+			Expect(v).To(Equal(raw.Value(`["h","e","l","l","o"]`)))
+		})
+
+		It("can fetch a list at a branch", func() {
+			v, ok := object(`{"a":{"b":{"c":[{"d":{"e":"h"}},{"d":{"e":"i"}},{"d":{"e":"!"}}]}}}`).Fetch(jsonryPath("a.b.c.d.e"))
+			Expect(ok).To(BeTrue())
+			Expect(v).To(Equal(raw.Value(`["h","i","!"]`)))
+		})
+
+		It("inserts nulls when a list has missing elements", func() {
+			v, ok := object(`{"a":{"b":{"c":[{"d":{"e":"h"}},{},{"d":{"e":"i"}},{"e":4},{"d":{"e":"!"}}]}}}`).Fetch(jsonryPath("a.b.c.d.e"))
+			Expect(ok).To(BeTrue())
+			Expect(v).To(Equal(raw.Value(`["h",null,"i",null,"!"]`)))
+		})
+
+		It("flattens lists of lists", func() {
+			v, ok := object(`{"a":{"b":{"c":[[{"d":{"e":"h"}}],[{}],{"d":{"e":"i"}},[],{"d":{"e":"!"}}]}}}`).Fetch(jsonryPath("a.b.c.d.e"))
+			Expect(ok).To(BeTrue())
+			Expect(v).To(Equal(raw.Value(`["h",null,"i","!"]`)))
+		})
+	})
+
+	Describe("Paths", func() {
+		read := func(s string, paths ...string) []raw.Value {
+			var p []path.Path
+			for _, s := range paths {
+				p = append(p, jsonryPath(s))
+			}
+			return raw.NewPaths(p).Read(parse(s))
+		}
+
+		It("reads the value at each path in the same way as Fetch", func() {
+			v := read(`{"a":{"b":1,"c":{"d":2}},"e":3,"l":[{"m":4},[{"m":5}],{}]}`, "a.b", "a.c.d", "e", "a.x", "l[].m", "a.c")
+			Expect(v).To(Equal([]raw.Value{
+				raw.Value(`1`),
+				raw.Value(`2`),
+				raw.Value(`3`),
+				nil,
+				raw.Value(`[4,5,null]`),
+				raw.Value(`{"d":2}`),
+			}))
+		})
+
+		It("returns the members that are not on any path", func() {
+			values, rest, err := raw.NewPaths([]path.Path{jsonryPath("a.b")}).ReadRest(parse(`{"x":1,"a":{"b":2,"c":3},"y":4}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal([]raw.Value{raw.Value(`2`)}))
+			Expect(rest).To(Equal(raw.Object{"x": raw.Value(`1`), "a": raw.Value(`{"c":3}`), "y": raw.Value(`4`)}))
+		})
+
+		It("returns the members of nested objects that are not on any path", func() {
+			paths := []path.Path{jsonryPath("a.b.c"), jsonryPath("a.d"), jsonryPath("e.f"), jsonryPath("g"), jsonryPath("g.h"), jsonryPath("l[].m")}
+			_, rest, err := raw.NewPaths(paths).ReadRest(parse(`{"a":{"b":{"c":1,"x":2},"d":3},"e":"s","g":{"h":4,"y":5},"l":[{"m":6,"z":7}]}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rest).To(Equal(raw.Object{"a": raw.Value(`{"b":{"x":2}}`), "e": raw.Value(`"s"`)}))
+		})
+
+		It("returns an error when a key cannot be decoded", func() {
+			_, _, err := raw.NewPaths([]path.Path{jsonryPath("a.b")}).ReadRest(raw.Value(`{"a":{"\x":1}}`))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,180 @@
+package tree
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+
+	"code.cloudfoundry.org/jsonry/internal/path"
+	"code.cloudfoundry.org/jsonry/internal/raw"
+)
+
+// Ordered is a JSON object which is marshaled with its keys in a specific order
+type Ordered struct {
+	keys   []string
+	values []interface{}
+}
+
+// Order returns the tree as an Ordered object. The keys at each level are in the order
+// in which they first appear in the paths, followed by any other keys in alphabetical order.
+func (t Tree) Order(paths []path.Path) Ordered {
+	var keys []string
+	seen := make(map[string]bool)
+	stems := make(map[string][]path.Path)
+	for _, p := range paths {
+		branch, stem := p.Pull()
+		if !seen[branch.Name] {
+			seen[branch.Name] = true
+			keys = append(keys, branch.Name)
+		}
+		if stem.Len() > 0 {
+			stems[branch.Name] = append(stems[branch.Name], stem)
+		}
+	}
+
+	var others []string
+	for k := range t {
+		if !seen[k] {
+			others = append(others, k)
+		}
+	}
+	sort.Strings(others)
+
+	var o Ordered
+	for _, k := range append(keys, others...) {
+		if v, ok := t[k]; ok {
+			o.keys = append(o.keys, k)
+			o.values = append(o.values, order(v, stems[k]))
+		}
+	}
+	return o
+}
+
+func (o Ordered) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i := range o.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		k, err := json.Marshal(o.keys[i])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(k)
+		b.WriteByte(':')
+
+		v, err := json.Marshal(o.values[i])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+func order(v interface{}, paths []path.Path) interface{} {
+	if len(paths) == 0 {
+		return v
+	}
+
+	switch vt := v.(type) {
+	case Tree:
+		return vt.Order(paths)
+	case []interface{}:
+		if vt == nil {
+			return vt
+		}
+		l := make([]interface{}, len(vt))
+		for i := range vt {
+			l[i] = order(vt[i], paths)
+		}
+		return l
+	default:
+		return v
+	}
+}
+
+// Insert sets the value at the path within a JSON object, which may be a Tree, a map, an Ordered
+// object, or the JSON for an object, and returns the object. Unlike Attach(), existing objects along
+// the path are kept, so that the other keys in them are not lost. When ordered is set, the JSON for
+// an object becomes an Ordered object that keeps the order of its keys, and a key that is added to an
+// Ordered object is written first. It reports false when the input is not a JSON object.
+func Insert(obj interface{}, p path.Path, v interface{}, ordered bool) (interface{}, bool) {
+	segment, stem := p.Pull()
+	value := func(existing interface{}, found bool) interface{} {
+		if stem.Len() == 0 {
+			return v
+		}
+		if found {
+			if r, ok := Insert(existing, stem, v, ordered); ok {
+				return r
+			}
+		}
+		return make(Tree).Attach(stem, v)
+	}
+
+	switch o := obj.(type) {
+	case json.RawMessage:
+		r, ok := object(o, ordered)
+		if !ok {
+			return obj, false
+		}
+		return Insert(r, p, v, ordered)
+	case map[string]interface{}:
+		return Insert(Tree(o), p, v, ordered)
+	case Tree:
+		e, ok := o[segment.Name]
+		o[segment.Name] = value(e, ok)
+		return o, true
+	case Ordered:
+		for i := range o.keys {
+			if o.keys[i] == segment.Name {
+				o.values[i] = value(o.values[i], true)
+				return o, true
+			}
+		}
+		o.keys = append([]string{segment.Name}, o.keys...)
+		o.values = append([]interface{}{value(nil, false)}, o.values...)
+		return o, true
+	default:
+		return obj, false
+	}
+}
+
+// object converts the JSON for an object into a Tree, or into an Ordered object with the keys in
+// the same order, with the values left as JSON
+func object(r json.RawMessage, ordered bool) (interface{}, bool) {
+	var (
+		t   = make(Tree)
+		o   Ordered
+		err error
+	)
+
+	ok := raw.Value(r).Members(func(key, value raw.Value) {
+		k, e := raw.Key(key)
+		if e != nil {
+			err = e
+			return
+		}
+
+		v := json.RawMessage(value)
+		if ordered {
+			o.keys = append(o.keys, k)
+			o.values = append(o.values, v)
+		} else {
+			t[k] = v
+		}
+	})
+
+	switch {
+	case !ok, err != nil:
+		return nil, false
+	case ordered:
+		return o, true
+	default:
+		return t, true
+	}
+}
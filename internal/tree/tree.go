@@ -2,9 +2,11 @@
 package tree
 
 import (
+	"encoding/json"
 	"reflect"
 
 	"code.cloudfoundry.org/jsonry/internal/path"
+	"code.cloudfoundry.org/jsonry/internal/raw"
 )
 
 type Tree map[string]interface{}
@@ -31,6 +33,36 @@ func (t Tree) Attach(p path.Path, v interface{}) Tree {
 	return t
 }
 
+// Branch finds the object at the path, creating it and any objects along the path that are
+// missing. It is not ok when the path has a list hint, or when there is a value along the path
+// that is not an object, because there is no single object at the path.
+func (t Tree) Branch(p path.Path) (Tree, bool) {
+	for q := p; q.Len() > 0; {
+		var s path.Segment
+		s, q = q.Pull()
+		if s.List {
+			return nil, false
+		}
+	}
+
+	for p.Len() > 0 {
+		branch, stem := p.Pull()
+		switch v := t[branch.Name].(type) {
+		case nil:
+			n := make(Tree)
+			t[branch.Name] = n
+			t = n
+		case Tree:
+			t = v
+		default:
+			return nil, false
+		}
+		p = stem
+	}
+
+	return t, true
+}
+
 func (t Tree) Fetch(p path.Path) (interface{}, bool) {
 	switch p.Len() {
 	case 0:
@@ -57,7 +89,13 @@ func (t Tree) Fetch(p path.Path) (interface{}, bool) {
 	}
 }
 
+// spread attaches each element of a list at the path within a new object. A value that is
+// not a list is treated as a list with one element, except that JSON null is an empty list.
 func spread(p path.Path, v interface{}) []interface{} {
+	if r, ok := v.(json.RawMessage); ok {
+		v = elements(r)
+	}
+
 	vv := reflect.ValueOf(v)
 	if vv.Kind() != reflect.Array && vv.Kind() != reflect.Slice {
 		v = []interface{}{v}
@@ -71,6 +109,25 @@ func spread(p path.Path, v interface{}) []interface{} {
 	return s
 }
 
+// elements splits the JSON for an array into the JSON for each element
+func elements(r json.RawMessage) []interface{} {
+	v := raw.Value(r)
+	if v.IsNull() {
+		return []interface{}(nil)
+	}
+
+	a, ok := v.Array()
+	if !ok {
+		return []interface{}{r}
+	}
+
+	l := make([]interface{}, len(a))
+	for i := range a {
+		l[i] = json.RawMessage(a[i])
+	}
+	return l
+}
+
 func unspread(v []interface{}, stem path.Path) []interface{} {
 	l := make([]interface{}, 0, len(v))
 	for i := range v {
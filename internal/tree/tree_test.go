@@ -31,6 +31,18 @@ var _ = Describe("Tree", func() {
 			Expect(json.Marshal(t)).To(MatchJSON(`{"a":{"b":[{"c":{"d":[{"e":"hello"}]}},{"c":{"d":[{"e":"world"}]}},{"c":{"d":[{"e":"!"}]}}]}}`))
 		})
 
+		It("spreads the JSON for a list", func() {
+			p := path.ComputePath(reflect.StructField{Tag: `jsonry:"a[].b"`})
+			t := make(tree.Tree).Attach(p, json.RawMessage(`[1,{"c":2}]`))
+			Expect(json.Marshal(t)).To(MatchJSON(`{"a":[{"b":1},{"b":{"c":2}}]}`))
+
+			t = make(tree.Tree).Attach(p, json.RawMessage(`"x"`))
+			Expect(json.Marshal(t)).To(MatchJSON(`{"a":[{"b":"x"}]}`))
+
+			t = make(tree.Tree).Attach(p, json.RawMessage(`null`))
+			Expect(json.Marshal(t)).To(MatchJSON(`{"a":null}`))
+		})
+
 		When("there is no list hint", func() {
 			It("creates lists at the leaf", func() {
 				p := path.ComputePath(reflect.StructField{Tag: `jsonry:"a.b.c.d.e"`})
@@ -40,6 +52,31 @@ var _ = Describe("Tree", func() {
 		})
 	})
 
+	Describe("Branch", func() {
+		p := path.Parse
+
+		It("finds or creates the objects along the path", func() {
+			t := tree.Tree{"a": tree.Tree{"b": 1}}
+			b, ok := t.Branch(p("a.c"))
+			Expect(ok).To(BeTrue())
+			b["d"] = 2
+			Expect(json.Marshal(t)).To(MatchJSON(`{"a":{"b":1,"c":{"d":2}}}`))
+
+			b, ok = t.Branch(path.Path{})
+			Expect(ok).To(BeTrue())
+			Expect(b).To(Equal(t))
+		})
+
+		It("says not ok for a list hint or a value that is not an object", func() {
+			t := tree.Tree{"a": 1}
+			_, ok := t.Branch(p("b[].c"))
+			Expect(ok).To(BeFalse())
+			_, ok = t.Branch(p("a.c"))
+			Expect(ok).To(BeFalse())
+			Expect(t).To(Equal(tree.Tree{"a": 1}))
+		})
+	})
+
 	Describe("Fetch", func() {
 		It("can fetch a basic value", func() {
 			var t tree.Tree
@@ -121,4 +158,75 @@ var _ = Describe("Tree", func() {
 			Expect(v).To(Equal([]interface{}{"h", nil, "i", "!"}))
 		})
 	})
+
+	Describe("Order", func() {
+		p := func(s string) path.Path {
+			return path.ComputePath(reflect.StructField{Tag: reflect.StructTag(`jsonry:"` + s + `"`)})
+		}
+
+		It("orders keys by first appearance in the paths", func() {
+			paths := []path.Path{p("z.b"), p("a"), p("z.a"), p("m[].y.b"), p("m[].y.a")}
+			t := make(tree.Tree)
+			for i, q := range paths[:3] {
+				t.Attach(q, []int{i})
+			}
+			t["m"] = []interface{}{tree.Tree{"y": tree.Tree{"a": 4, "b": 3}}}
+			t["other"] = 1
+			t["another"] = 2
+
+			Expect(json.Marshal(t.Order(paths))).To(Equal([]byte(`{"z":{"b":[0],"a":[2]},"a":[1],"m":[{"y":{"b":3,"a":4}}],"another":2,"other":1}`)))
+		})
+
+		It("skips paths that are not in the tree", func() {
+			t := make(tree.Tree).Attach(p("b"), 1)
+			Expect(json.Marshal(t.Order([]path.Path{p("a"), p("b")}))).To(Equal([]byte(`{"b":1}`)))
+		})
+	})
+
+	Describe("Insert", func() {
+		p := path.Parse
+
+		It("keeps existing objects along the path", func() {
+			obj := map[string]interface{}{"a": map[string]interface{}{"b": 1}, "c": 2}
+			r, ok := tree.Insert(obj, p("a.d"), "x", false)
+			Expect(ok).To(BeTrue())
+			Expect(json.Marshal(r)).To(MatchJSON(`{"a":{"b":1,"d":"x"},"c":2}`))
+		})
+
+		It("replaces a value that is not an object", func() {
+			r, ok := tree.Insert(tree.Tree{"a": 1}, p("a.b"), "x", false)
+			Expect(ok).To(BeTrue())
+			Expect(json.Marshal(r)).To(MatchJSON(`{"a":{"b":"x"}}`))
+		})
+
+		It("writes a new key first in an Ordered object", func() {
+			o := tree.Tree{"z": tree.Tree{"y": 1}, "a": 2}.Order([]path.Path{p("z.y"), p("a")})
+			r, ok := tree.Insert(o, p("type"), "x", true)
+			Expect(ok).To(BeTrue())
+			Expect(json.Marshal(r)).To(Equal([]byte(`{"type":"x","z":{"y":1},"a":2}`)))
+
+			r, ok = tree.Insert(o, p("z.w"), "x", true)
+			Expect(ok).To(BeTrue())
+			Expect(json.Marshal(r)).To(Equal([]byte(`{"z":{"w":"x","y":1},"a":2}`)))
+		})
+
+		It("inserts into the JSON for an object", func() {
+			obj := json.RawMessage(`{"z":{"y":1},"a":2}`)
+			r, ok := tree.Insert(obj, p("z.w"), "x", false)
+			Expect(ok).To(BeTrue())
+			Expect(json.Marshal(r)).To(Equal([]byte(`{"a":2,"z":{"w":"x","y":1}}`)))
+
+			r, ok = tree.Insert(obj, p("type"), "x", true)
+			Expect(ok).To(BeTrue())
+			Expect(json.Marshal(r)).To(Equal([]byte(`{"type":"x","z":{"y":1},"a":2}`)))
+		})
+
+		It("says not ok when the input is not an object", func() {
+			_, ok := tree.Insert([]interface{}{1}, p("a"), "x", false)
+			Expect(ok).To(BeFalse())
+
+			_, ok = tree.Insert(json.RawMessage(`[1]`), p("a"), "x", false)
+			Expect(ok).To(BeFalse())
+		})
+	})
 })
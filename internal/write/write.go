@@ -0,0 +1,76 @@
+// Package write writes JSON values to a buffer in exactly the same way as encoding/json
+package write
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Float follows the formatting rules of encoding/json, which are those of ES6. It reports false,
+// and writes nothing, for NaN and infinity, which cannot be represented in JSON.
+func Float(b *bytes.Buffer, f float64, bits int) bool {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return false
+	}
+
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+
+	var a [64]byte
+	r := strconv.AppendFloat(a[:0], f, format, -1, bits)
+	if format == 'e' {
+		// clean up e-09 to e-9
+		if n := len(r); n >= 4 && r[n-4] == 'e' && r[n-3] == '-' && r[n-2] == '0' {
+			r[n-2] = r[n-1]
+			r = r[:n-1]
+		}
+	}
+
+	b.Write(r)
+	return true
+}
+
+// String writes a JSON string. Strings which need escaping other than for quotes and
+// backslashes are rare, so they are written by json.Marshal() to get exactly the same escaping.
+func String(b *bytes.Buffer, s string) {
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			if c < 0x20 || c == '<' || c == '>' || c == '&' {
+				m, _ := json.Marshal(s)
+				b.Write(m)
+				return
+			}
+			i++
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError || r == '\u2028' || r == '\u2029' {
+			m, _ := json.Marshal(s)
+			b.Write(m)
+			return
+		}
+		i += size
+	}
+
+	b.WriteByte('"')
+	start := 0
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c == '"' || c == '\\' {
+			b.WriteString(s[start:i])
+			b.WriteByte('\\')
+			b.WriteByte(c)
+			start = i + 1
+		}
+	}
+	b.WriteString(s[start:])
+	b.WriteByte('"')
+}
@@ -39,4 +39,9 @@
 //
 //	Go: s := struct { Foo string `jsonry:"foo.bar"` }{Foo: "value"}
 //	JSON: {"foo": {"bar": "value"} }
+//
+// The Marshal() and Unmarshal() functions use default settings. The behavior can be modified by
+// using the methods on a Config. For example:
+//
+//	data, err := jsonry.Config{OmitEmpty: true}.Marshal(s)
 package jsonry
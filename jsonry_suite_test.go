@@ -6,6 +6,7 @@ import (
 	"errors"
 	"testing"
 
+	"code.cloudfoundry.org/jsonry"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -31,12 +32,74 @@ func (i *implementsJSONUnmarshaler) UnmarshalJSON(input []byte) error {
 	return nil
 }
 
+type recordsJSON struct {
+	input []byte
+}
+
+func (r *recordsJSON) UnmarshalJSON(input []byte) error {
+	r.input = append([]byte(nil), input...)
+	return nil
+}
+
 type implementsOmissible string
 
 func (i implementsOmissible) OmitJSONry() bool {
 	return i == "omit"
 }
 
+type omitsNil struct{}
+
+func (o *omitsNil) OmitJSONry() bool {
+	return o == nil
+}
+
+type recordsPresence struct {
+	Name  string `json:"name"`
+	found bool
+	null  bool
+}
+
+func (r *recordsPresence) SetPresent(found, null bool) {
+	r.found = found
+	r.null = null
+}
+
+// relationship writes its type as a sibling key, which needs access to the parent object
+type relationship struct {
+	GUID    string
+	Type    string
+	Options []string
+}
+
+func (r relationship) MarshalJSONry(ctx jsonry.Context) (interface{}, error) {
+	if r.GUID == "fail" {
+		return nil, errors.New("ouch")
+	}
+	if ctx.Parent != nil {
+		ctx.Parent[ctx.Key+"_type"] = r.Type
+	}
+	return r.GUID, nil
+}
+
+func (r *relationship) UnmarshalJSONry(ctx jsonry.Context, data []byte) error {
+	if err := json.Unmarshal(data, &r.GUID); err != nil {
+		return err
+	}
+	r.Type, _ = ctx.Parent[ctx.Key+"_type"].(string)
+	r.Options = ctx.Options
+	return nil
+}
+
+// recordsParent records the parent object from the Context
+type recordsParent struct {
+	parent map[string]interface{}
+}
+
+func (r *recordsParent) UnmarshalJSONry(ctx jsonry.Context, data []byte) error {
+	r.parent = ctx.Parent
+	return nil
+}
+
 type nullString struct {
 	value string
 	null  bool
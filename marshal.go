@@ -9,46 +9,86 @@ import (
 	"code.cloudfoundry.org/jsonry/internal/tree"
 )
 
-// Marshal converts the specified Go struct into JSON. The input must be a struct or a pointer to a struct.
+// Marshal converts the specified Go value into JSON. The input is typically a struct or a pointer to a struct,
+// and may also be a slice, array, map or basic type, in which case any structs that it holds are marshaled
+// using their JSONry paths. A nil pointer is marshaled as null.
 // Where a field is optional, the suffix ",omitempty" can be specified. This will mean that the field will
 // be omitted from the JSON output if it is a nil pointer or has zero value for the type.
+// When Config.OmitDefaults is set, a field with a default value is omitted when it is equal to the default.
 // When a field is a slice or an array, a single list hint "[]" may be specified in the JSONry path so that the array
 // is created at the correct position in the JSON output.
 //
-// If a type implements the json.Marshaler interface, then the MarshalJSON() method will be called.
+// A []byte is marshaled as a base64 string in the same way as encoding/json. The tag option ",base64url"
+// selects URL-safe base64, and the tag option ",hex" selects hexadecimal.
+//
+// A json.RawMessage is written to the output in the same way as encoding/json, so it preserves the key order
+// and number formatting, but is compacted, and the characters <, > and & are escaped, for example as \u003c.
+//
+// If a type implements the jsonry.JSONryMarshaler interface, then the MarshalJSONry() method will be called
+// with a Context describing the path of the field, the tag options, and the JSON object that contains the field.
+//
+// If a type implements the json.Marshaler interface, then the MarshalJSON() method will be called,
+// and the output will be written without being decoded and encoded again.
+//
+// A jsonry.Optional is omitted when it is unset, written as null when it is null, and otherwise
+// marshaled in the same way as the value that it holds.
+//
+// A field tagged ",remain" must be a map with string keys, and its entries are written as keys of the JSON
+// object for the struct. Where another field has written the same key, the field takes priority and the entry
+// is not written, except that an entry which is a JSON object is merged into an object made by the paths of
+// other fields, so that a remainder from Unmarshal is written back to the same place.
+//
+// When there is a Union for an interface type in Config.Unions, the discriminator for the concrete type
+// is written into the JSON object for the value. When an interface field is tagged ",typed", the name of
+// the concrete type from Config.Types is written in the same way.
 //
 // If a type implements the jsonry.Omissible interface, then the OmitJSONry() method will be used to
 // to determine whether or not to marshal the field, overriding any `,omitempty` tags.
 //
 // The field type can be string, bool, int*, uint*, float*, map, slice, array or struct. JSONry is recursive.
+// Fields of type complex64 and complex128 can be marshaled by setting Config.ComplexEncoding.
 func Marshal(in interface{}) ([]byte, error) {
-	iv := reflect.Indirect(reflect.ValueOf(in))
-
-	if iv.Kind() != reflect.Struct {
-		return nil, fmt.Errorf(`the input must be a struct, not "%s"`, iv.Kind())
-	}
+	return Config{}.Marshal(in)
+}
 
-	m, err := marshalStruct(iv)
-	if err != nil {
-		return nil, err
+// Marshal works in the same way as the package-level Marshal() function, but using the
+// settings from the Config.
+func (c Config) Marshal(in interface{}) ([]byte, error) {
+	v := reflect.ValueOf(in)
+	if iv := reflect.Indirect(v); iv.Kind() == reflect.Struct {
+		v = iv
 	}
 
-	return json.Marshal(m)
+	return c.encode(v)
 }
 
-func marshalStruct(in reflect.Value) (map[string]interface{}, error) {
+// marshalStruct builds the JSON object for a struct as a tree. It is used instead of writing the
+// object directly when a field adds keys to the parent object, or fields have conflicting paths.
+func (c *Config) marshalStruct(in reflect.Value, defaults map[int]reflect.Value) (interface{}, error) {
 	out := make(tree.Tree)
 	t := in.Type()
+	var rest *remainder
+	var paths []path.Path
 
 	for i := 0; i < t.NumField(); i++ {
 		f := t.Field(i)
 
 		if public(f) {
-			path := path.ComputePath(f)
-			val := in.Field(i)
+			path := c.computePath(f)
+			if path.Option(remainOption) {
+				rest = &remainder{index: i, path: path}
+				continue
+			}
 
-			if shouldMarshal(path, val) {
-				r, err := marshal(val)
+			if !path.OmitAlways {
+				paths = append(paths, path)
+			}
+
+			val := in.Field(i)
+			if c.shouldMarshal(path, val, defaults[i]) {
+				opts := newTagOptions(path)
+				opts.parent = out
+				r, err := c.marshal(val, opts)
 				if err != nil {
 					return nil, wrapErrorWithFieldContext(err, f.Name, f.Type)
 				}
@@ -58,73 +98,40 @@ func marshalStruct(in reflect.Value) (map[string]interface{}, error) {
 		}
 	}
 
-	return out, nil
-}
-
-func marshal(in reflect.Value) (r interface{}, err error) {
-	input := reflect.Indirect(in)
-	kind := input.Kind()
+	if rest != nil {
+		if err := c.marshalRemainder(out, in, *rest); err != nil {
+			return nil, err
+		}
+	}
 
-	switch {
-	case kind == reflect.Invalid:
-		r = nil
-	case input.Type().Implements(reflect.TypeOf((*json.Marshaler)(nil)).Elem()):
-		r, err = marshalJSONMarshaler(input)
-	case kind == reflect.Interface:
-		r, err = marshal(input.Elem())
-	case basicType(kind):
-		r = in.Interface()
-	case kind == reflect.Struct:
-		r, err = marshalStruct(input)
-	case kind == reflect.Slice || kind == reflect.Array:
-		r, err = marshalList(input)
-	case kind == reflect.Map:
-		r, err = marshalMap(input)
-	default:
-		err = newUnsupportedTypeError(input.Type())
+	if c.DeclarationOrder {
+		return out.Order(paths), nil
 	}
 
-	return
+	return map[string]interface{}(out), nil
 }
 
-func marshalList(in reflect.Value) (out []interface{}, err error) {
-	if in.Type().Kind() == reflect.Slice && in.IsNil() {
-		return out, nil
-	}
-
-	out = make([]interface{}, in.Len())
-	for i := 0; i < in.Len(); i++ {
-		r, err := marshal(in.Index(i))
-		if err != nil {
-			return nil, wrapErrorWithIndexContext(err, i, in.Type())
-		}
-		out[i] = r
+// marshal converts a value into JSON using the encoder, which is the only place where
+// the way that a type is marshaled is decided
+func (c *Config) marshal(in reflect.Value, opts tagOptions) (json.RawMessage, error) {
+	e := encoder{config: c}
+	if err := e.encode(in, opts); err != nil {
+		return nil, err
 	}
-
-	return out, nil
+	return e.buf.Bytes(), nil
 }
 
-func marshalMap(in reflect.Value) (out map[string]interface{}, err error) {
+func marshalRawMessage(in reflect.Value) (interface{}, error) {
 	if in.IsNil() {
-		return out, nil
+		return nil, nil
 	}
 
-	out = make(map[string]interface{})
-	iter := in.MapRange()
-	for iter.Next() {
-		k := iter.Key()
-		if k.Kind() != reflect.String {
-			return nil, newUnsupportedKeyTypeError(in.Type())
-		}
-
-		r, err := marshal(iter.Value())
-		if err != nil {
-			return nil, wrapErrorWithKeyContext(err, k.String(), k.Type())
-		}
-		out[k.String()] = r
+	r := in.Bytes()
+	if err := checkJSON(r); err != nil {
+		return nil, newForeignError(fmt.Sprintf(`error parsing json.RawMessage "%s"`, r), err)
 	}
 
-	return out, nil
+	return json.RawMessage(r), nil
 }
 
 func marshalJSONMarshaler(in reflect.Value) (interface{}, error) {
@@ -135,22 +142,28 @@ func marshalJSONMarshaler(in reflect.Value) (interface{}, error) {
 		return nil, newForeignError(fmt.Sprintf("error from %s() call", method), err)
 	}
 
-	var r interface{}
-	err := json.Unmarshal(t[0].Bytes(), &r)
-	if err != nil {
-		return nil, newForeignError(fmt.Sprintf(`error parsing %s() output "%s"`, method, t[0].Bytes()), err)
+	r := t[0].Bytes()
+	if err := checkJSON(r); err != nil {
+		return nil, newForeignError(fmt.Sprintf(`error parsing %s() output "%s"`, method, r), err)
 	}
 
-	return r, nil
+	return json.RawMessage(r), nil
 }
 
-func shouldMarshal(p path.Path, v reflect.Value) bool {
+// shouldMarshal reports whether a field is written, where def is the default value of the field
+// when Config.OmitDefaults is set and the field has a default
+func (c *Config) shouldMarshal(p path.Path, v reflect.Value, def reflect.Value) bool {
 	switch {
 	case p.OmitAlways:
 		return false
-	case v.Type().Implements(reflect.TypeOf((*Omissible)(nil)).Elem()):
+	case v.Kind() == reflect.Ptr && v.IsNil() && implementsAny(v.Type().Elem(), optionalValuerType, omissibleType):
+		// OmitJSONry() can only be called for a nil pointer when it has a pointer receiver
+		return !(p.OmitEmpty || c.OmitEmpty)
+	case v.Type().Implements(omissibleType):
 		return !v.MethodByName("OmitJSONry").Call(nil)[0].Bool()
-	case p.OmitEmpty && isEmpty(v):
+	case def.IsValid() && reflect.DeepEqual(def.Interface(), v.Interface()):
+		return false
+	case (p.OmitEmpty || c.OmitEmpty) && isEmpty(v):
 		return false
 	default:
 		return true
@@ -164,7 +177,7 @@ func isEmpty(v reflect.Value) bool {
 		return v.IsZero() || v.IsNil()
 	case k == reflect.String, k == reflect.Map, k == reflect.Slice, k == reflect.Array:
 		return v.Len() == 0
-	case basicType(k):
+	case basicType(k), complexType(k):
 		return v.IsZero()
 	default:
 		return false
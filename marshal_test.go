@@ -3,6 +3,10 @@ package jsonry_test
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
 
 	"code.cloudfoundry.org/jsonry"
 	. "github.com/onsi/ginkgo/v2"
@@ -108,6 +112,26 @@ var _ = Describe("Marshal", func() {
 			expectToMarshal(struct{ E, F float64 }{E: 4.2e-15, F: 4.2}, `{"E":4.2e-15,"F":4.2}`)
 		})
 
+		It("marshals a json.Number as a number", func() {
+			expectToMarshal(struct{ N, Z json.Number }{N: "42.5e1"}, `{"N":42.5e1,"Z":0}`)
+			expectToMarshal(struct{ N interface{} }{N: json.Number("-1")}, `{"N":-1}`)
+			expectToMarshal(struct{ N *json.Number }{N: new(json.Number)}, `{"N":0}`)
+		})
+
+		It("does not marshal an invalid json.Number", func() {
+			_, expected := json.Marshal(json.Number("forty-two"))
+			Expect(expected).To(HaveOccurred())
+
+			_, err := jsonry.Marshal(struct{ N json.Number }{N: "forty-two"})
+			Expect(err).To(MatchError(expected.Error()))
+		})
+
+		It("round trips numbers when UseNumber is set", func() {
+			var s map[string]interface{}
+			Expect(jsonry.Config{UseNumber: true}.Unmarshal([]byte(`{"a":1,"b":[2.50]}`), &s)).To(Succeed())
+			Expect(jsonry.Marshal(s)).To(MatchJSON(`{"a":1,"b":[2.50]}`))
+		})
+
 		It("does not marshal a complex64", func() {
 			expectToFail(struct{ C complex64 }{C: complex(1, 2)}, `unsupported type "complex64" at field "C" (type "complex64")`)
 		})
@@ -211,6 +235,56 @@ var _ = Describe("Marshal", func() {
 			})
 		})
 
+		Context("byte slices", func() {
+			It("marshals a []byte as base64", func() {
+				b := []byte("hello\xff")
+				expectToMarshal(struct{ B []byte }{B: b}, `{"B":"aGVsbG//"}`)
+				expectToMarshal(struct{ B *[]byte }{B: &b}, `{"B":"aGVsbG//"}`)
+				expectToMarshal(struct{ B []byte }{B: []byte{}}, `{"B":""}`)
+				expectToMarshal(struct{ B []byte }{}, `{"B":null}`)
+			})
+
+			It("marshals a []byte as URL-safe base64", func() {
+				s := struct {
+					B []byte `jsonry:"b,base64url"`
+				}{B: []byte("hello\xff")}
+				expectToMarshal(s, `{"b":"aGVsbG__"}`)
+			})
+
+			It("marshals a []byte as hex", func() {
+				s := struct {
+					B []byte `json:"b,hex,omitempty"`
+					C []byte `jsonry:"c.d,omitempty,hex"`
+				}{C: []byte{0xde, 0xad, 0xbe, 0xef}}
+				expectToMarshal(s, `{"c":{"d":"deadbeef"}}`)
+			})
+
+			It("applies the encoding to slices and maps of []byte", func() {
+				s := struct {
+					L [][]byte          `jsonry:",hex"`
+					M map[string][]byte `jsonry:",hex"`
+				}{
+					L: [][]byte{{1}, {2, 3}},
+					M: map[string][]byte{"a": {0xff}},
+				}
+				expectToMarshal(s, `{"L":["01","0203"],"M":{"a":"ff"}}`)
+			})
+
+			It("marshals named byte slice types", func() {
+				type bytes []byte
+				expectToMarshal(struct{ B bytes }{B: bytes("hi")}, `{"B":"aGk="}`)
+			})
+
+			It("marshals slices with a named byte element type", func() {
+				type myByte byte
+				expectToMarshal(struct{ B []myByte }{B: []myByte{1, 2}}, `{"B":"AQI="}`)
+			})
+
+			It("marshals a byte array as an array", func() {
+				expectToMarshal(struct{ B [2]byte }{B: [2]byte{1, 2}}, `{"B":[1,2]}`)
+			})
+		})
+
 		It("marshals a json.Marshaler", func() {
 			expectToMarshal(struct{ I implementsJSONMarshaler }{I: implementsJSONMarshaler{bytes: []byte(`"hello"`)}}, `{"I":"hello"}`)
 			expectToMarshal(struct{ I *implementsJSONMarshaler }{I: &implementsJSONMarshaler{bytes: []byte(`"hello"`)}}, `{"I":"hello"}`)
@@ -220,6 +294,72 @@ var _ = Describe("Marshal", func() {
 			expectToFail(struct{ I implementsJSONMarshaler }{I: implementsJSONMarshaler{}}, `error parsing MarshalJSON() output "" at field "I" (type "jsonry_test.implementsJSONMarshaler"): unexpected end of JSON input`)
 		})
 
+		It("writes the json.Marshaler output without decoding it", func() {
+			s := struct {
+				I implementsJSONMarshaler `jsonry:"a.b"`
+			}{I: implementsJSONMarshaler{bytes: []byte(`{"z": 1, "a": 12345678901234567890}`)}}
+
+			out, err := jsonry.Marshal(s)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(out)).To(Equal(`{"a":{"b":{"z":1,"a":12345678901234567890}}}`))
+		})
+
+		It("marshals a JSONryMarshaler with a context", func() {
+			expectToMarshal(struct {
+				R relationship   `jsonry:"space"`
+				P *relationship  `jsonry:"org"`
+				N relationship   `jsonry:"relationships.app.data"`
+				S []relationship `jsonry:"items[].data"`
+				L []relationship
+			}{
+				R: relationship{GUID: "space-guid", Type: "space"},
+				P: &relationship{GUID: "org-guid", Type: "organization"},
+				N: relationship{GUID: "app-guid", Type: "app"},
+				S: []relationship{{GUID: "item-guid", Type: "ignored"}},
+				L: []relationship{{GUID: "other-guid", Type: "ignored"}},
+			}, `{
+				"space":"space-guid","space_type":"space",
+				"org":"org-guid","org_type":"organization",
+				"relationships":{"app":{"data":"app-guid","data_type":"app"}},
+				"items":[{"data":"item-guid"}],
+				"L":["other-guid"]
+			}`)
+
+			expectToFail(struct{ R relationship }{R: relationship{GUID: "fail"}}, `error from MarshalJSONry() call at field "R" (type "jsonry_test.relationship"): ouch`)
+		})
+
+		It("marshals a json.RawMessage preserving key order and number formatting", func() {
+			r := json.RawMessage(`{"z": 1, "a": [1.50e1, "\u00e9"]}`)
+			s := struct {
+				R json.RawMessage  `jsonry:"a.b"`
+				P *json.RawMessage `jsonry:"a.c"`
+				N json.RawMessage
+			}{R: r, P: &r}
+
+			out, err := jsonry.Marshal(s)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(out)).To(Equal(`{"N":null,"a":{"b":{"z":1,"a":[1.50e1,"\u00e9"]},"c":{"z":1,"a":[1.50e1,"\u00e9"]}}}`))
+
+			expectToFail(
+				struct{ R json.RawMessage }{R: json.RawMessage(`{"a"`)},
+				fmt.Sprintf(`error parsing json.RawMessage "{"a"" at field "R" (type "%s"): unexpected end of JSON input`, reflect.TypeOf(r)),
+			)
+		})
+
+		It("compacts and escapes a json.RawMessage in the same way as encoding/json", func() {
+			s := struct {
+				R json.RawMessage `jsonry:"a.b"`
+			}{R: json.RawMessage("{ \"a\" :\n\t\"<&>\" }")}
+
+			out, err := jsonry.Marshal(s)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(out)).To(Equal(`{"a":{"b":{"a":"\u003c\u0026\u003e"}}}`))
+
+			expected, err := json.Marshal(s.R)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(ContainSubstring(string(expected)))
+		})
+
 		It("marshals from named types and type aliases", func() {
 			type alias = string
 			type named string
@@ -393,6 +533,231 @@ var _ = Describe("Marshal", func() {
 			}
 			expectToMarshal(s, `{"A": ""}`)
 		})
+
+		It("is called for a nil pointer", func() {
+			s := struct {
+				A *omitsNil
+				B *omitsNil
+			}{
+				B: &omitsNil{},
+			}
+			expectToMarshal(s, `{"B": {}}`)
+		})
+
+		It("is not called for a nil pointer when it has a value receiver", func() {
+			s := struct {
+				A *implementsOmissible
+				B *implementsOmissible `jsonry:",omitempty"`
+			}{}
+			expectToMarshal(s, `{"A": null}`)
+		})
+	})
+
+	Describe("remain", func() {
+		It("merges the entries into the object", func() {
+			expectToMarshal(struct {
+				Name  string                 `jsonry:"name"`
+				GUID  string                 `jsonry:"metadata.guid"`
+				Other map[string]interface{} `jsonry:",remain"`
+			}{
+				Name:  "foo",
+				GUID:  "bar",
+				Other: map[string]interface{}{"name": "ignored", "state": "STARTED", "links": map[string]interface{}{"self": "baz"}},
+			}, `{"name":"foo","metadata":{"guid":"bar"},"state":"STARTED","links":{"self":"baz"}}`)
+		})
+
+		It("merges objects into the objects made by the paths of fields", func() {
+			expectToMarshal(struct {
+				GUID  string                 `jsonry:"metadata.guid"`
+				Child map[string]int         `jsonry:"child"`
+				Other map[string]interface{} `jsonry:",remain"`
+			}{
+				GUID:  "bar",
+				Child: map[string]int{"a": 1},
+				Other: map[string]interface{}{
+					"metadata": map[string]interface{}{"guid": "ignored", "labels": json.RawMessage(`{"x":"y"}`)},
+					"child":    map[string]interface{}{"b": 2},
+				},
+			}, `{"metadata":{"guid":"bar","labels":{"x":"y"}},"child":{"a":1}}`)
+		})
+
+		It("can be nil", func() {
+			expectToMarshal(struct {
+				Name  string                 `jsonry:"name"`
+				Other map[string]interface{} `jsonry:",remain"`
+			}{Name: "foo"}, `{"name":"foo"}`)
+		})
+
+		It("must be a map", func() {
+			expectToFail(struct {
+				Other string `jsonry:",remain"`
+			}{}, `unsupported type "string" at field "Other" (type "string")`)
+		})
+	})
+
+	Describe("config", func() {
+		When("DeclarationOrder is set", func() {
+			config := jsonry.Config{DeclarationOrder: true}
+
+			It("writes keys in the order that the fields are declared", func() {
+				type child struct {
+					Z string
+					A string
+				}
+				s := struct {
+					Name    string   `jsonry:"name"`
+					GUID    string   `jsonry:"metadata.guid"`
+					Child   child    `jsonry:"child"`
+					Labels  string   `jsonry:"metadata.labels.a"`
+					Omitted string   `jsonry:"metadata.omitted,omitempty"`
+					Routes  []string `jsonry:"routes[].url"`
+					Map     map[string]int
+					Other   map[string]interface{} `jsonry:",remain"`
+					Apps    []child                `jsonry:"apps"`
+				}{
+					Name:   "foo",
+					GUID:   "bar",
+					Child:  child{Z: "z", A: "a"},
+					Labels: "baz",
+					Routes: []string{"r1", "r2"},
+					Map:    map[string]int{"b": 2, "a": 1},
+					Other:  map[string]interface{}{"y": 1, "x": 2},
+					Apps:   []child{{Z: "1", A: "2"}},
+				}
+
+				out, err := config.Marshal(s)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(out)).To(Equal(`{"name":"foo","metadata":{"guid":"bar","labels":{"a":"baz"}},"child":{"Z":"z","A":"a"},"routes":[{"url":"r1"},{"url":"r2"}],"Map":{"a":1,"b":2},"apps":[{"Z":"1","A":"2"}],"x":2,"y":1}`))
+			})
+
+			It("produces the same JSON as the default", func() {
+				s := struct {
+					B string `jsonry:"b.d"`
+					A string `jsonry:"b.c"`
+				}{B: "foo", A: "bar"}
+
+				out, err := config.Marshal(s)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(out)).To(Equal(`{"b":{"d":"foo","c":"bar"}}`))
+				Expect(jsonry.Marshal(s)).To(MatchJSON(out))
+			})
+		})
+
+		When("OmitEmpty is set", func() {
+			config := jsonry.Config{OmitEmpty: true}
+
+			It("omits empty fields without an omitempty tag", func() {
+				s := struct {
+					A string
+					B int    `json:"bee"`
+					C []int  `jsonry:"c.d"`
+					D string `jsonry:"d.e"`
+					E bool
+				}{D: "foo"}
+				out, err := config.Marshal(s)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(out).To(MatchJSON(`{"d":{"e":"foo"}}`))
+			})
+
+			It("still respects the Omissible interface", func() {
+				s := struct{ A implementsOmissible }{A: ""}
+				out, err := config.Marshal(s)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(out).To(MatchJSON(`{"A":""}`))
+			})
+		})
+
+		When("Tags is set", func() {
+			type s struct {
+				A string `json:"a" jsonry:"b.c"`
+				B string `cf:"d.e"`
+				C string
+			}
+
+			It("reads the tags in the specified order", func() {
+				out, err := jsonry.Config{Tags: []string{"jsonry", "json"}}.Marshal(s{A: "foo", B: "bar", C: "baz"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(out).To(MatchJSON(`{"b":{"c":"foo"},"B":"bar","C":"baz"}`))
+			})
+
+			It("reads a custom tag", func() {
+				out, err := jsonry.Config{Tags: []string{"cf"}}.Marshal(s{A: "foo", B: "bar", C: "baz"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(out).To(MatchJSON(`{"A":"foo","d":{"e":"bar"},"C":"baz"}`))
+			})
+		})
+
+		When("ComplexEncoding is set", func() {
+			It("marshals complex numbers as arrays", func() {
+				s := struct {
+					C complex64
+					D *complex128 `jsonry:"d.e"`
+				}{C: complex(1.1, -2), D: new(complex128)}
+				out, err := jsonry.Config{ComplexEncoding: jsonry.ComplexArray}.Marshal(s)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(out).To(MatchJSON(`{"C":[1.1,-2],"d":{"e":[0,0]}}`))
+			})
+
+			It("marshals complex numbers as objects", func() {
+				s := struct {
+					C []complex128
+				}{C: []complex128{complex(1, 2), complex(3.5, 4e-10)}}
+				out, err := jsonry.Config{ComplexEncoding: jsonry.ComplexObject}.Marshal(s)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(out).To(MatchJSON(`{"C":[{"real":1,"imag":2},{"real":3.5,"imag":4e-10}]}`))
+			})
+
+			It("omits zero complex numbers with omitempty", func() {
+				s := struct {
+					C complex64 `jsonry:",omitempty"`
+				}{}
+				out, err := jsonry.Config{ComplexEncoding: jsonry.ComplexArray}.Marshal(s)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(out).To(MatchJSON(`{}`))
+			})
+		})
+
+		It("behaves like Marshal() when it is the zero value", func() {
+			s := struct {
+				A string
+				B string `jsonry:"b.c,omitempty"`
+			}{}
+			out, err := jsonry.Config{}.Marshal(s)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(MatchJSON(`{"A":""}`))
+		})
+	})
+
+	Describe("output", func() {
+		It("writes values in exactly the same way as encoding/json", func() {
+			type s struct {
+				Strings []string           `json:"strings"`
+				Floats  []float64          `json:"floats"`
+				Small   []float32          `json:"small"`
+				Ints    []int64            `json:"ints"`
+				Map     map[string]float64 `json:"map"`
+				Time    time.Time          `json:"time"`
+				Raw     json.RawMessage    `json:"raw"`
+			}
+			in := s{
+				Strings: []string{"plain", `quotes " and \\ backslashes`, "<html> & stuff", "control\n\t\u0001", "unicode é ☃ \u2028", "invalid \xff"},
+				Floats:  []float64{0, -0.5, 1e20, 1e21, 1e-6, 1e-7, 123456789.125, 1.5e-300},
+				Small:   []float32{0.1, 1e-7, 3.4e38},
+				Ints:    []int64{math.MinInt64, -1, 0, math.MaxInt64},
+				Map:     map[string]float64{"b": 2, "a<": 1, "é": 3},
+				Time:    time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC),
+				Raw:     json.RawMessage(`{ "a" : "<" }`),
+			}
+
+			expected, err := json.Marshal(in)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jsonry.Config{DeclarationOrder: true}.Marshal(in)).To(Equal(expected))
+		})
+
+		It("fails in the same way as encoding/json for values that cannot be represented", func() {
+			_, err := jsonry.Marshal(struct{ F float64 }{F: math.Inf(1)})
+			Expect(err).To(MatchError("json: unsupported value: +Inf"))
+		})
 	})
 
 	Describe("inputs", func() {
@@ -408,16 +773,87 @@ var _ = Describe("Marshal", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
-		It("rejects a non-struct value", func() {
-			_, err := jsonry.Marshal(42)
-			Expect(err).To(MatchError(`the input must be a struct, not "int"`))
-		})
-
-		It("rejects a nil pointer", func() {
+		It("marshals a nil pointer as null", func() {
 			type s struct{}
 			var sp *s
-			_, err := jsonry.Marshal(sp)
-			Expect(err).To(MatchError(`the input must be a struct, not "invalid"`))
+			expectToMarshal(sp, `null`)
+			expectToMarshal(nil, `null`)
+		})
+
+		It("marshals a slice of structs using their paths", func() {
+			type app struct {
+				Name      string `jsonry:"name"`
+				SpaceGUID string `jsonry:"relationships.space.data.guid"`
+			}
+			expectToMarshal([]app{{Name: "a", SpaceGUID: "1"}, {Name: "b", SpaceGUID: "2"}}, `[
+				{"name": "a", "relationships": {"space": {"data": {"guid": "1"}}}},
+				{"name": "b", "relationships": {"space": {"data": {"guid": "2"}}}}
+			]`)
+			expectToMarshal(&[]*app{{Name: "a"}, nil}, `[{"name": "a", "relationships": {"space": {"data": {"guid": ""}}}}, null]`)
+		})
+
+		It("marshals a map of structs using their paths", func() {
+			type space struct {
+				OrgGUID string `jsonry:"relationships.org.data.guid"`
+			}
+			expectToMarshal(map[string]space{"s": {OrgGUID: "o"}}, `{"s": {"relationships": {"org": {"data": {"guid": "o"}}}}}`)
+		})
+
+		It("marshals basic types", func() {
+			expectToMarshal(42, `42`)
+			expectToMarshal("hello", `"hello"`)
+			expectToMarshal([]int{1, 2}, `[1,2]`)
+			expectToMarshal(json.RawMessage(`{"a": 1}`), `{"a": 1}`)
+		})
+
+		It("reports errors with the index", func() {
+			expectToFail([]interface{}{1, func() {}}, `unsupported type "func()" at index 1 (type "[]interface {}")`)
+			expectToFail(42i, `unsupported type "complex128" at root path`)
+		})
+	})
+
+	Describe("MarshalAt", func() {
+		type app struct {
+			Name      string `jsonry:"name"`
+			SpaceGUID string `jsonry:"relationships.space.data.guid"`
+		}
+
+		It("writes the input at the path", func() {
+			out, err := jsonry.MarshalAt(app{Name: "a", SpaceGUID: "s"}, "data.attributes")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(MatchJSON(`{"data":{"attributes":{"name":"a","relationships":{"space":{"data":{"guid":"s"}}}}}}`))
+		})
+
+		It("writes a slice at the path", func() {
+			out, err := jsonry.MarshalAt([]app{{Name: "a"}, {Name: "b"}}, "resources")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(MatchJSON(`{"resources":[
+				{"name":"a","relationships":{"space":{"data":{"guid":""}}}},
+				{"name":"b","relationships":{"space":{"data":{"guid":""}}}}
+			]}`))
+		})
+
+		It("spreads a slice when the path has a list hint", func() {
+			out, err := jsonry.MarshalAt([]string{"a", "b"}, "data[].guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(MatchJSON(`{"data":[{"guid":"a"},{"guid":"b"}]}`))
+		})
+
+		It("is the same as Marshal() for an empty path", func() {
+			out, err := jsonry.MarshalAt(app{Name: "a"}, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(MatchJSON(`{"name":"a","relationships":{"space":{"data":{"guid":""}}}}`))
+		})
+
+		It("uses the Config", func() {
+			out, err := jsonry.Config{OmitEmpty: true}.MarshalAt(app{Name: "a"}, "data")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(MatchJSON(`{"data":{"name":"a"}}`))
+		})
+
+		It("returns errors", func() {
+			_, err := jsonry.MarshalAt(struct{ F func() }{}, "data")
+			Expect(err).To(MatchError(`unsupported type "func()" at field "F" (type "func()")`))
 		})
 	})
 })
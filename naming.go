@@ -0,0 +1,66 @@
+package jsonry
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SnakeCase is a naming strategy for Config.Naming that converts a Go field name into snake_case.
+// For example "AppGUID" becomes "app_guid".
+func SnakeCase(name string) string {
+	return strings.Join(lowerWords(name), "_")
+}
+
+// KebabCase is a naming strategy for Config.Naming that converts a Go field name into kebab-case.
+// For example "AppGUID" becomes "app-guid".
+func KebabCase(name string) string {
+	return strings.Join(lowerWords(name), "-")
+}
+
+// CamelCase is a naming strategy for Config.Naming that converts a Go field name into camelCase.
+// For example "AppGUID" becomes "appGuid".
+func CamelCase(name string) string {
+	words := lowerWords(name)
+	for i := 1; i < len(words); i++ {
+		r := []rune(words[i])
+		r[0] = unicode.ToUpper(r[0])
+		words[i] = string(r)
+	}
+	return strings.Join(words, "")
+}
+
+func lowerWords(name string) []string {
+	words := splitWords(name)
+	for i := range words {
+		words[i] = strings.ToLower(words[i])
+	}
+	return words
+}
+
+// splitWords splits a Go identifier into words, treating a run of upper case letters as an acronym,
+// so that "HTTPServerID" becomes "HTTP", "Server", "ID"
+func splitWords(name string) (words []string) {
+	r := []rune(name)
+	start := 0
+	for i := 1; i < len(r); i++ {
+		switch {
+		case r[i] == '_' || r[i] == '-':
+			if start < i {
+				words = append(words, string(r[start:i]))
+			}
+			start = i + 1
+		case unicode.IsUpper(r[i]) && !unicode.IsUpper(r[i-1]) && r[i-1] != '_' && r[i-1] != '-':
+			words = append(words, string(r[start:i]))
+			start = i
+		case unicode.IsUpper(r[i]) && i+1 < len(r) && unicode.IsLower(r[i+1]) && unicode.IsUpper(r[i-1]):
+			words = append(words, string(r[start:i]))
+			start = i
+		}
+	}
+
+	if start < len(r) {
+		words = append(words, string(r[start:]))
+	}
+
+	return words
+}
@@ -0,0 +1,50 @@
+package jsonry_test
+
+import (
+	"code.cloudfoundry.org/jsonry"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("naming strategies", func() {
+	DescribeTable("converting field names",
+		func(name, snake, kebab, camel string) {
+			Expect(jsonry.SnakeCase(name)).To(Equal(snake))
+			Expect(jsonry.KebabCase(name)).To(Equal(kebab))
+			Expect(jsonry.CamelCase(name)).To(Equal(camel))
+		},
+		Entry("single word", "Name", "name", "name", "name"),
+		Entry("multiple words", "SpaceName", "space_name", "space-name", "spaceName"),
+		Entry("acronym", "GUID", "guid", "guid", "guid"),
+		Entry("trailing acronym", "AppGUID", "app_guid", "app-guid", "appGuid"),
+		Entry("leading acronym", "HTTPServer", "http_server", "http-server", "httpServer"),
+		Entry("acronyms between words", "HTTPServerIDValue", "http_server_id_value", "http-server-id-value", "httpServerIdValue"),
+		Entry("digits", "Route53Zone", "route53_zone", "route53-zone", "route53Zone"),
+		Entry("underscores", "Space_Name", "space_name", "space-name", "spaceName"),
+		Entry("lower case", "name", "name", "name", "name"),
+	)
+
+	It("is applied by the Config to fields without a tag name", func() {
+		type s struct {
+			SpaceName string
+			AppGUID   string `jsonry:",omitempty"`
+			OrgName   string `jsonry:"organization.name"`
+		}
+
+		config := jsonry.Config{Naming: jsonry.SnakeCase}
+		out, err := config.Marshal(s{SpaceName: "foo", AppGUID: "bar", OrgName: "baz"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(MatchJSON(`{"space_name":"foo","app_guid":"bar","organization":{"name":"baz"}}`))
+
+		var r s
+		Expect(config.Unmarshal(out, &r)).To(Succeed())
+		Expect(r).To(Equal(s{SpaceName: "foo", AppGUID: "bar", OrgName: "baz"}))
+	})
+
+	It("can be a custom function", func() {
+		config := jsonry.Config{Naming: func(s string) string { return "x-" + s }}
+		out, err := config.Marshal(struct{ Foo string }{Foo: "bar"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(MatchJSON(`{"x-Foo":"bar"}`))
+	})
+})
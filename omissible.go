@@ -1,10 +1,15 @@
 package jsonry
 
+import "reflect"
+
 // Omissible is the interface implemented by types that indicate
 // whether they should be omitted when being marshaled. It
 // allows for more custom control than the `omitempty` tag.
 // This interface overrides any `omitempty` behavior, and it is
 // not necessary to specify `omitempty` with an Omissible type.
+// For a nil pointer, OmitJSONry() is only called when it has a pointer receiver.
 type Omissible interface {
 	OmitJSONry() bool
 }
+
+var omissibleType = reflect.TypeOf((*Omissible)(nil)).Elem()
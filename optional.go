@@ -0,0 +1,123 @@
+package jsonry
+
+import (
+	"reflect"
+
+	"code.cloudfoundry.org/jsonry/internal/raw"
+)
+
+type optionalState int
+
+const (
+	optionalUnset optionalState = iota
+	optionalNull
+	optionalSet
+)
+
+// Optional is a value which distinguishes between being unset, being explicitly null,
+// and being set to a value. This matches APIs where omitting a field and sending null
+// for a field have different meanings. The zero value is unset.
+//
+// When marshaling, an unset Optional is omitted, a null Optional is written as null,
+// and a set Optional is marshaled in the same way as its value would be.
+//
+// When unmarshaling, an Optional is unset if its path is not in the JSON, null if the
+// JSON value is null, and set otherwise.
+type Optional[T any] struct {
+	value T
+	state optionalState
+}
+
+// Some returns an Optional that is set to the value
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{value: v, state: optionalSet}
+}
+
+// Null returns an Optional that is explicitly null
+func Null[T any]() Optional[T] {
+	return Optional[T]{state: optionalNull}
+}
+
+// Get returns the value, and whether the Optional is set
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.state == optionalSet
+}
+
+// IsSet reports whether the Optional has a value
+func (o Optional[T]) IsSet() bool {
+	return o.state == optionalSet
+}
+
+// IsNull reports whether the Optional is explicitly null
+func (o Optional[T]) IsNull() bool {
+	return o.state == optionalNull
+}
+
+// IsUnset reports whether the Optional is neither set nor null
+func (o Optional[T]) IsUnset() bool {
+	return o.state == optionalUnset
+}
+
+// OmitJSONry implements the Omissible interface so that an unset Optional is omitted
+func (o Optional[T]) OmitJSONry() bool {
+	return o.state == optionalUnset
+}
+
+func (o Optional[T]) optionalValue() (reflect.Value, bool) {
+	if o.state != optionalSet {
+		return reflect.Value{}, false
+	}
+	return reflect.ValueOf(&o.value).Elem(), true
+}
+
+func (o *Optional[T]) setOptional(v reflect.Value) {
+	if !v.IsValid() {
+		*o = Null[T]()
+		return
+	}
+	*o = Some(v.Interface().(T))
+}
+
+func (o *Optional[T]) optionalType() reflect.Type {
+	return reflect.TypeOf(&o.value).Elem()
+}
+
+// optionalValuer and optionalSetter are implemented by Optional[T] so that the value is
+// marshaled and unmarshaled by JSONry, which means that it can contain JSONry paths
+type optionalValuer interface {
+	optionalValue() (reflect.Value, bool)
+}
+
+type optionalSetter interface {
+	setOptional(reflect.Value)
+	optionalType() reflect.Type
+}
+
+var (
+	optionalValuerType = reflect.TypeOf((*optionalValuer)(nil)).Elem()
+	optionalSetterType = reflect.TypeOf((*optionalSetter)(nil)).Elem()
+)
+
+func (c *Config) unmarshalIntoOptional(target reflect.Value, found bool, source raw.Value, opts tagOptions) error {
+	if !found {
+		return nil
+	}
+
+	o := c.allocateIfNeeded(target).Addr().Interface().(optionalSetter)
+	if source.IsNull() {
+		o.setOptional(reflect.Value{})
+		return nil
+	}
+
+	v := reflect.New(o.optionalType()).Elem()
+	if existing, ok := o.(optionalValuer).optionalValue(); c.Merge && ok {
+		v.Set(existing)
+	}
+
+	if err := c.unmarshal(v, true, source, opts); err != nil {
+		return err
+	}
+
+	o.setOptional(v)
+	return nil
+}
@@ -0,0 +1,118 @@
+package jsonry_test
+
+import (
+	"code.cloudfoundry.org/jsonry"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Optional", func() {
+	type child struct {
+		Name string `jsonry:"metadata.name"`
+	}
+
+	type s struct {
+		A jsonry.Optional[string]   `jsonry:"a"`
+		B jsonry.Optional[int]      `jsonry:"deeply.nested.b"`
+		C jsonry.Optional[child]    `jsonry:"c"`
+		D *jsonry.Optional[float64] `jsonry:"d"`
+	}
+
+	It("reports its state", func() {
+		var unset jsonry.Optional[string]
+		Expect(unset.IsUnset()).To(BeTrue())
+		Expect(unset.IsNull()).To(BeFalse())
+		Expect(unset.IsSet()).To(BeFalse())
+
+		null := jsonry.Null[string]()
+		Expect(null.IsUnset()).To(BeFalse())
+		Expect(null.IsNull()).To(BeTrue())
+		Expect(null.IsSet()).To(BeFalse())
+
+		set := jsonry.Some("hello")
+		Expect(set.IsUnset()).To(BeFalse())
+		Expect(set.IsNull()).To(BeFalse())
+		Expect(set.IsSet()).To(BeTrue())
+
+		v, ok := set.Get()
+		Expect(ok).To(BeTrue())
+		Expect(v).To(Equal("hello"))
+	})
+
+	Describe("marshaling", func() {
+		It("omits unset values", func() {
+			Expect(jsonry.Marshal(s{D: &jsonry.Optional[float64]{}})).To(MatchJSON(`{}`))
+		})
+
+		It("writes null values", func() {
+			d := jsonry.Null[float64]()
+			out, err := jsonry.Marshal(s{
+				A: jsonry.Null[string](),
+				B: jsonry.Null[int](),
+				C: jsonry.Null[child](),
+				D: &d,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(MatchJSON(`{"a":null,"deeply":{"nested":{"b":null}},"c":null,"d":null}`))
+		})
+
+		It("writes set values", func() {
+			d := jsonry.Some(4.5)
+			out, err := jsonry.Marshal(s{
+				A: jsonry.Some("hello"),
+				B: jsonry.Some(42),
+				C: jsonry.Some(child{Name: "foo"}),
+				D: &d,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(MatchJSON(`{"a":"hello","deeply":{"nested":{"b":42}},"c":{"metadata":{"name":"foo"}},"d":4.5}`))
+		})
+
+		It("writes a nil pointer as null", func() {
+			Expect(jsonry.Marshal(s{})).To(MatchJSON(`{"d":null}`))
+		})
+
+		It("writes unset values in lists as null", func() {
+			out, err := jsonry.Marshal(struct {
+				L []jsonry.Optional[int]
+			}{L: []jsonry.Optional[int]{jsonry.Some(1), {}, jsonry.Null[int]()}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(MatchJSON(`{"L":[1,null,null]}`))
+		})
+	})
+
+	Describe("unmarshaling", func() {
+		It("leaves absent values unset", func() {
+			var r s
+			Expect(jsonry.Unmarshal([]byte(`{"deeply":{}}`), &r)).To(Succeed())
+			Expect(r.A.IsUnset()).To(BeTrue())
+			Expect(r.B.IsUnset()).To(BeTrue())
+			Expect(r.C.IsUnset()).To(BeTrue())
+			Expect(r.D).To(BeNil())
+		})
+
+		It("reads null values", func() {
+			var r s
+			Expect(jsonry.Unmarshal([]byte(`{"a":null,"deeply":{"nested":{"b":null}},"c":null,"d":null}`), &r)).To(Succeed())
+			Expect(r.A.IsNull()).To(BeTrue())
+			Expect(r.B.IsNull()).To(BeTrue())
+			Expect(r.C.IsNull()).To(BeTrue())
+			Expect(r.D.IsNull()).To(BeTrue())
+		})
+
+		It("reads set values", func() {
+			var r s
+			Expect(jsonry.Unmarshal([]byte(`{"a":"hello","deeply":{"nested":{"b":42}},"c":{"metadata":{"name":"foo"}},"d":4.5}`), &r)).To(Succeed())
+			Expect(r.A).To(Equal(jsonry.Some("hello")))
+			Expect(r.B).To(Equal(jsonry.Some(42)))
+			Expect(r.C).To(Equal(jsonry.Some(child{Name: "foo"})))
+			Expect(*r.D).To(Equal(jsonry.Some(4.5)))
+		})
+
+		It("fails when the value has the wrong type", func() {
+			var r s
+			err := jsonry.Unmarshal([]byte(`{"deeply":{"nested":{"b":"hello"}}}`), &r)
+			Expect(err).To(MatchError(`cannot unmarshal "hello" type "string" into field "B" (type "jsonry.Optional[int]")`))
+		})
+	})
+})
@@ -0,0 +1,10 @@
+package jsonry
+
+// Presence is the interface implemented by types that record whether
+// their path was present in the JSON when being unmarshaled. The
+// SetPresent() method is called after the value has been unmarshaled,
+// with found indicating that the path existed, and null indicating that
+// the value at the path was null. It is not called on a nil pointer.
+type Presence interface {
+	SetPresent(found, null bool)
+}
@@ -0,0 +1,89 @@
+package jsonry
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"code.cloudfoundry.org/jsonry/internal/path"
+	"code.cloudfoundry.org/jsonry/internal/raw"
+	"code.cloudfoundry.org/jsonry/internal/tree"
+)
+
+const remainOption = "remain"
+
+// remainder is a field tagged ",remain" which holds the keys of a JSON object that
+// are not used by any other field in the struct
+type remainder struct {
+	index int
+	path  path.Path
+}
+
+func (c *Config) marshalRemainder(out tree.Tree, in reflect.Value, r remainder) error {
+	f := in.Type().Field(r.index)
+	v, err := c.marshal(in.Field(r.index), newTagOptions(r.path))
+	if err != nil {
+		return wrapErrorWithFieldContext(err, f.Name, f.Type)
+	}
+
+	if raw.Value(v).IsNull() {
+		return nil
+	}
+
+	m, ok := remainderObject(v)
+	if !ok {
+		return wrapErrorWithFieldContext(newUnsupportedTypeError(f.Type), f.Name, f.Type)
+	}
+
+	mergeRemainder(out, m)
+	return nil
+}
+
+// mergeRemainder writes the entries of the remainder into the object. Where another field has
+// written a key, the field takes priority, except that when both are JSON objects, and the
+// object was created by the paths of fields, then the entries are merged into it.
+func mergeRemainder(out tree.Tree, rest map[string]interface{}) {
+	for k, v := range rest {
+		existing, ok := out[k]
+		if !ok {
+			out[k] = v
+			continue
+		}
+
+		if t, ok := existing.(tree.Tree); ok {
+			if obj, ok := remainderObject(v); ok {
+				mergeRemainder(t, obj)
+			}
+		}
+	}
+}
+
+func remainderObject(v interface{}) (map[string]interface{}, bool) {
+	switch o := v.(type) {
+	case map[string]interface{}:
+		return o, true
+	case json.RawMessage:
+		obj, ok := raw.Value(o).Object()
+		if !ok {
+			return nil, false
+		}
+
+		m := make(map[string]interface{}, len(obj))
+		for k, v := range obj {
+			m[k] = json.RawMessage(v)
+		}
+		return m, true
+	default:
+		return nil, false
+	}
+}
+
+func (c *Config) unmarshalRemainder(target reflect.Value, rest raw.Object, r remainder, at *location) error {
+	f := target.Type().Field(r.index)
+	opts := newTagOptions(r.path)
+	opts.at = c.locate(at, location{sort: fieldLocation, name: f.Name, typ: f.Type})
+	if err := c.unmarshal(target.Field(r.index), len(rest) > 0, rest.Value(), opts); err != nil {
+		return wrapErrorWithFieldContext(err, f.Name, f.Type)
+	}
+
+	return nil
+}
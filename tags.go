@@ -0,0 +1,45 @@
+package jsonry
+
+import (
+	"code.cloudfoundry.org/jsonry/internal/path"
+	"code.cloudfoundry.org/jsonry/internal/tree"
+)
+
+const (
+	hexOption       = "hex"
+	base64URLOption = "base64url"
+	typedOption     = "typed"
+)
+
+// tagOptions are the options from a struct tag that apply to the value of a field,
+// including the elements when the field is a slice, array or map. They also record
+// where the field is, so that a Context can be passed to JSONry interfaces.
+type tagOptions struct {
+	bytes  byteEncoding
+	typed  bool
+	path   path.Path
+	parent tree.Tree
+	object *sourceObject
+	at     *location
+}
+
+func newTagOptions(p path.Path) tagOptions {
+	o := tagOptions{path: p, typed: p.Option(typedOption)}
+
+	switch {
+	case p.Option(hexOption):
+		o.bytes = hexEncoding
+	case p.Option(base64URLOption):
+		o.bytes = base64URLEncoding
+	}
+
+	return o
+}
+
+// element returns the options for the elements of a slice, array or map, which do
+// not have a parent object
+func (o tagOptions) element() tagOptions {
+	o.parent = nil
+	o.object = nil
+	return o
+}
@@ -0,0 +1,180 @@
+package jsonry
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"code.cloudfoundry.org/jsonry/internal/path"
+	"code.cloudfoundry.org/jsonry/internal/raw"
+	"code.cloudfoundry.org/jsonry/internal/tree"
+)
+
+const defaultTypeKey = "$type"
+
+// Union specifies how values of an interface type are marshaled and unmarshaled when the concrete type
+// is identified by a discriminator in the JSON, for example "type": "docker". Unions are specified in
+// Config.Unions. For example:
+//
+//	jsonry.Union{
+//		Type:          reflect.TypeFor[Lifecycle](),
+//		Discriminator: "type",
+//		Variants: map[string]reflect.Type{
+//			"buildpack": reflect.TypeFor[BuildpackLifecycle](),
+//			"docker":    reflect.TypeFor[*DockerLifecycle](),
+//		},
+//	}
+//
+// When unmarshaling, the discriminator is read from the JSON object, and the object is unmarshaled into
+// a new value of the matching concrete type. When marshaling, the concrete type is marshaled, and the
+// discriminator is written into the JSON object.
+type Union struct {
+	// Type is the interface type that the Union applies to
+	Type reflect.Type
+
+	// Discriminator is the JSONry path of the discriminator within the JSON object for a value,
+	// for example "type" or "lifecycle.type". The discriminator is a JSON string.
+	Discriminator string
+
+	// Variants maps each value of the discriminator to a concrete type, which must implement the
+	// interface. A concrete type is typically a struct or a pointer to a struct.
+	Variants map[string]reflect.Type
+}
+
+// unionFor finds the Union for an interface type, where a field tagged ",typed" has a Union
+// made from Config.Types
+func (c *Config) unionFor(t reflect.Type, opts tagOptions) (Union, bool) {
+	if t.Kind() != reflect.Interface {
+		return Union{}, false
+	}
+
+	if opts.typed {
+		key := c.TypeKey
+		if key == "" {
+			key = defaultTypeKey
+		}
+		return Union{Type: t, Discriminator: key, Variants: c.Types}, true
+	}
+
+	for _, u := range c.Unions {
+		if u.Type == t {
+			return u, true
+		}
+	}
+	return Union{}, false
+}
+
+// unionName finds the discriminator value for a concrete type. A pointer is also matched by the type
+// that it points to, and where more than one name has the same type, the first alphabetically is used.
+func (c *Config) unionName(u Union, t reflect.Type) (string, bool) {
+	names := c.variantNames(u)
+	if n, ok := names[t]; ok {
+		return n, true
+	}
+
+	if t.Kind() == reflect.Ptr {
+		n, ok := names[t.Elem()]
+		return n, ok
+	}
+	return "", false
+}
+
+// variantNames maps the concrete types of a Union to their discriminator values. The maps are kept
+// for the duration of a call to Marshal, keyed by the Variants map, which the Config refers to.
+func (c *Config) variantNames(u Union) map[reflect.Type]string {
+	key := reflect.ValueOf(u.Variants).UnsafePointer()
+	if names, ok := c.unionNames[key]; ok {
+		return names
+	}
+
+	sorted := make([]string, 0, len(u.Variants))
+	for n := range u.Variants {
+		sorted = append(sorted, n)
+	}
+	sort.Strings(sorted)
+
+	names := make(map[reflect.Type]string, len(sorted))
+	for _, n := range sorted {
+		if _, ok := names[u.Variants[n]]; !ok {
+			names[u.Variants[n]] = n
+		}
+	}
+
+	if c.unionNames != nil {
+		c.unionNames[key] = names
+	}
+	return names
+}
+
+func (c *Config) marshalUnion(u Union, in reflect.Value, opts tagOptions) (interface{}, error) {
+	if in.IsNil() {
+		return nil, nil
+	}
+
+	concrete := in.Elem()
+	name, ok := c.unionName(u, concrete.Type())
+	if !ok {
+		return nil, newUnionError(fmt.Sprintf(`no discriminator for type "%s" in union "%s"`, concrete.Type(), u.Type))
+	}
+
+	r, err := c.marshal(concrete, opts)
+	if err != nil || raw.Value(r).IsNull() {
+		return r, err
+	}
+
+	obj, ok := tree.Insert(r, path.Parse(u.Discriminator), name, c.DeclarationOrder)
+	if !ok {
+		return nil, newUnionError(fmt.Sprintf(`cannot write discriminator "%s" for type "%s" which is not a JSON object`, u.Discriminator, concrete.Type()))
+	}
+	return obj, nil
+}
+
+func (c *Config) unmarshalIntoUnion(u Union, target reflect.Value, found bool, source raw.Value, opts tagOptions) error {
+	switch {
+	case !found:
+		return nil
+	case source.IsNull():
+		return setZeroValue(target)
+	}
+
+	obj, ok := source.Object()
+	if !ok {
+		return newSourceConversionError(source)
+	}
+
+	d, ok := obj.Fetch(path.Parse(u.Discriminator))
+	if !ok || d.IsNull() {
+		return newUnionError(fmt.Sprintf(`missing discriminator "%s" for union "%s"`, u.Discriminator, u.Type))
+	}
+
+	dv, err := decodeSource(d)
+	if err != nil {
+		return err
+	}
+
+	name, ok := dv.(string)
+	if !ok {
+		return newUnionError(fmt.Sprintf(`discriminator "%s" value "%+v" is not a string for union "%s"`, u.Discriminator, dv, u.Type))
+	}
+
+	t, ok := u.Variants[name]
+	switch {
+	case !ok:
+		return newUnionError(fmt.Sprintf(`unknown discriminator "%s" value "%+v" for union "%s"`, u.Discriminator, dv, u.Type))
+	case !t.AssignableTo(u.Type):
+		return newUnionError(fmt.Sprintf(`type "%s" does not implement union "%s"`, t, u.Type))
+	}
+
+	dst := c.allocateIfNeeded(target)
+	v := reflect.New(t).Elem()
+	if c.Merge && !dst.IsNil() && dst.Elem().Type() == t {
+		v.Set(dst.Elem())
+	}
+
+	if err := c.unmarshal(v, true, source, tagOptions{at: opts.at}); err != nil {
+		return err
+	}
+
+	dst.Set(v)
+	return nil
+}
@@ -0,0 +1,302 @@
+package jsonry_test
+
+import (
+	"reflect"
+
+	"code.cloudfoundry.org/jsonry"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type lifecycle interface {
+	stack() string
+}
+
+type buildpackLifecycle struct {
+	Buildpacks []string `jsonry:"data.buildpacks"`
+	Stack      string   `jsonry:"data.stack"`
+}
+
+func (b buildpackLifecycle) stack() string { return b.Stack }
+
+type dockerLifecycle struct {
+	Image string `jsonry:"data.image"`
+}
+
+func (d *dockerLifecycle) stack() string { return "" }
+
+type unknownLifecycle struct{}
+
+func (unknownLifecycle) stack() string { return "" }
+
+type event interface{}
+
+type appCreated struct {
+	GUID string `jsonry:"app.guid"`
+}
+
+type appNote string
+
+type appRenamed struct {
+	From string `jsonry:"names.from"`
+	To   string `jsonry:"names.to"`
+}
+
+var _ = Describe("unions", func() {
+	union := jsonry.Union{
+		Type:          reflect.TypeFor[lifecycle](),
+		Discriminator: "type",
+		Variants: map[string]reflect.Type{
+			"buildpack": reflect.TypeFor[buildpackLifecycle](),
+			"docker":    reflect.TypeFor[*dockerLifecycle](),
+		},
+	}
+	config := jsonry.Config{Unions: []jsonry.Union{union}}
+
+	type app struct {
+		Name       string      `jsonry:"name"`
+		Lifecycle  lifecycle   `jsonry:"lifecycle"`
+		Lifecycles []lifecycle `jsonry:"history[].lifecycle"`
+	}
+
+	Describe("unmarshaling", func() {
+		It("selects the concrete type using the discriminator", func() {
+			var a app
+			Expect(config.Unmarshal([]byte(`{
+				"name": "a",
+				"lifecycle": {"type": "buildpack", "data": {"buildpacks": ["go"], "stack": "cflinuxfs4"}},
+				"history": [{"lifecycle": {"type": "docker", "data": {"image": "nginx"}}}]
+			}`), &a)).To(Succeed())
+
+			Expect(a).To(Equal(app{
+				Name:       "a",
+				Lifecycle:  buildpackLifecycle{Buildpacks: []string{"go"}, Stack: "cflinuxfs4"},
+				Lifecycles: []lifecycle{&dockerLifecycle{Image: "nginx"}},
+			}))
+		})
+
+		It("reads a nested discriminator", func() {
+			var l lifecycle
+			c := jsonry.Config{Unions: []jsonry.Union{{
+				Type:          union.Type,
+				Discriminator: "meta.kind",
+				Variants:      union.Variants,
+			}}}
+			Expect(c.Unmarshal([]byte(`{"meta":{"kind":"docker"},"data":{"image":"nginx"}}`), &l)).To(Succeed())
+			Expect(l).To(Equal(&dockerLifecycle{Image: "nginx"}))
+		})
+
+		It("handles null and missing values", func() {
+			a := app{Lifecycle: buildpackLifecycle{}}
+			Expect(config.Unmarshal([]byte(`{"name":"a"}`), &a)).To(Succeed())
+			Expect(a.Lifecycle).To(Equal(buildpackLifecycle{}))
+
+			Expect(config.Unmarshal([]byte(`{"lifecycle":null}`), &a)).To(Succeed())
+			Expect(a.Lifecycle).To(BeNil())
+		})
+
+		It("keeps the existing value when merging into the same type", func() {
+			a := app{Lifecycle: buildpackLifecycle{Stack: "cflinuxfs4"}}
+			c := config
+			c.Merge = true
+			Expect(c.Unmarshal([]byte(`{"lifecycle":{"type":"buildpack","data":{"buildpacks":["go"]}}}`), &a)).To(Succeed())
+			Expect(a.Lifecycle).To(Equal(buildpackLifecycle{Buildpacks: []string{"go"}, Stack: "cflinuxfs4"}))
+		})
+
+		It("does not affect interfaces without a Union", func() {
+			var s struct{ I interface{} }
+			Expect(config.Unmarshal([]byte(`{"I":{"type":"docker"}}`), &s)).To(Succeed())
+			Expect(s.I).To(Equal(map[string]interface{}{"type": "docker"}))
+		})
+
+		It("fails when the discriminator is missing or unknown", func() {
+			var a app
+			err := config.Unmarshal([]byte(`{"lifecycle":{"data":{}}}`), &a)
+			Expect(err).To(MatchError(`missing discriminator "type" for union "jsonry_test.lifecycle" at field "Lifecycle" (type "jsonry_test.lifecycle")`))
+
+			err = config.Unmarshal([]byte(`{"history":[{"lifecycle":{"type":"kpack"}}]}`), &a)
+			Expect(err).To(MatchError(`unknown discriminator "type" value "kpack" for union "jsonry_test.lifecycle" at index 0 (type "jsonry_test.lifecycle") path Lifecycles[0]`))
+
+			err = config.Unmarshal([]byte(`{"lifecycle":"docker"}`), &a)
+			Expect(err).To(MatchError(`cannot unmarshal "docker" type "string" into field "Lifecycle" (type "jsonry_test.lifecycle")`))
+		})
+
+		It("fails when the discriminator is not a string", func() {
+			var a app
+			err := config.Unmarshal([]byte(`{"lifecycle":{"type":42}}`), &a)
+			Expect(err).To(MatchError(`discriminator "type" value "42" is not a string for union "jsonry_test.lifecycle" at field "Lifecycle" (type "jsonry_test.lifecycle")`))
+
+			err = config.Unmarshal([]byte(`{"lifecycle":{"type":["docker"]}}`), &a)
+			Expect(err).To(MatchError(`discriminator "type" value "[docker]" is not a string for union "jsonry_test.lifecycle" at field "Lifecycle" (type "jsonry_test.lifecycle")`))
+		})
+
+		It("fails when a variant does not implement the interface", func() {
+			c := jsonry.Config{Unions: []jsonry.Union{{
+				Type:          union.Type,
+				Discriminator: "type",
+				Variants:      map[string]reflect.Type{"docker": reflect.TypeFor[dockerLifecycle]()},
+			}}}
+
+			var a app
+			err := c.Unmarshal([]byte(`{"lifecycle":{"type":"docker"}}`), &a)
+			Expect(err).To(MatchError(`type "jsonry_test.dockerLifecycle" does not implement union "jsonry_test.lifecycle" at field "Lifecycle" (type "jsonry_test.lifecycle")`))
+		})
+	})
+
+	Describe("marshaling", func() {
+		It("writes the discriminator", func() {
+			out, err := config.Marshal(app{
+				Name:       "a",
+				Lifecycle:  buildpackLifecycle{Buildpacks: []string{"go"}, Stack: "cflinuxfs4"},
+				Lifecycles: []lifecycle{&dockerLifecycle{Image: "nginx"}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(MatchJSON(`{
+				"name": "a",
+				"lifecycle": {"type": "buildpack", "data": {"buildpacks": ["go"], "stack": "cflinuxfs4"}},
+				"history": [{"lifecycle": {"type": "docker", "data": {"image": "nginx"}}}]
+			}`))
+		})
+
+		It("writes the discriminator first in declaration order", func() {
+			c := config
+			c.DeclarationOrder = true
+			out, err := c.Marshal(app{Name: "a", Lifecycle: buildpackLifecycle{Stack: "s"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(out)).To(Equal(`{"name":"a","lifecycle":{"type":"buildpack","data":{"buildpacks":null,"stack":"s"}},"history":null}`))
+		})
+
+		It("round trips", func() {
+			in := app{Lifecycle: &dockerLifecycle{Image: "nginx"}, Lifecycles: []lifecycle{buildpackLifecycle{Stack: "s"}}}
+			data, err := config.Marshal(in)
+			Expect(err).NotTo(HaveOccurred())
+
+			var out app
+			Expect(config.Unmarshal(data, &out)).To(Succeed())
+			Expect(out).To(Equal(in))
+		})
+
+		It("uses the first name alphabetically when names have the same type, and matches a pointer by its element type", func() {
+			c := jsonry.Config{Unions: []jsonry.Union{{
+				Type:          union.Type,
+				Discriminator: "type",
+				Variants: map[string]reflect.Type{
+					"pack":      reflect.TypeFor[buildpackLifecycle](),
+					"buildpack": reflect.TypeFor[buildpackLifecycle](),
+					"docker":    reflect.TypeFor[*dockerLifecycle](),
+				},
+			}}}
+
+			out, err := c.Marshal(app{Lifecycle: &buildpackLifecycle{}, Lifecycles: []lifecycle{buildpackLifecycle{}}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(MatchJSON(`{
+				"name": "",
+				"lifecycle": {"type": "buildpack", "data": {"buildpacks": null, "stack": ""}},
+				"history": [{"lifecycle": {"type": "buildpack", "data": {"buildpacks": null, "stack": ""}}}]
+			}`))
+		})
+
+		It("writes null for a nil value", func() {
+			out, err := config.Marshal(app{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(MatchJSON(`{"name":"","lifecycle":null,"history":null}`))
+		})
+
+		It("fails for a type that is not in the Union", func() {
+			_, err := config.Marshal(app{Lifecycle: unknownLifecycle{}})
+			Expect(err).To(MatchError(`no discriminator for type "jsonry_test.unknownLifecycle" in union "jsonry_test.lifecycle" at field "Lifecycle" (type "jsonry_test.lifecycle")`))
+		})
+	})
+
+	Describe("typed fields", func() {
+		config := jsonry.Config{Types: map[string]reflect.Type{
+			"created": reflect.TypeFor[appCreated](),
+			"renamed": reflect.TypeFor[*appRenamed](),
+		}}
+
+		type log struct {
+			Last   event            `jsonry:"last,typed"`
+			Events []event          `jsonry:"events,typed"`
+			ByGUID map[string]event `jsonry:"by_guid,typed"`
+			Other  event            `jsonry:"other"`
+		}
+
+		It("round trips a list of different types", func() {
+			in := log{
+				Last:   appCreated{GUID: "a"},
+				Events: []event{appCreated{GUID: "a"}, &appRenamed{From: "b", To: "c"}},
+				ByGUID: map[string]event{"a": &appRenamed{To: "d"}},
+				Other:  appCreated{GUID: "e"},
+			}
+
+			data, err := config.Marshal(in)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(MatchJSON(`{
+				"last": {"$type": "created", "app": {"guid": "a"}},
+				"events": [
+					{"$type": "created", "app": {"guid": "a"}},
+					{"$type": "renamed", "names": {"from": "b", "to": "c"}}
+				],
+				"by_guid": {"a": {"$type": "renamed", "names": {"from": "", "to": "d"}}},
+				"other": {"app": {"guid": "e"}}
+			}`))
+
+			var out log
+			Expect(config.Unmarshal(data, &out)).To(Succeed())
+			Expect(out.Last).To(Equal(in.Last))
+			Expect(out.Events).To(Equal(in.Events))
+			Expect(out.ByGUID).To(Equal(in.ByGUID))
+			Expect(out.Other).To(Equal(map[string]interface{}{"app": map[string]interface{}{"guid": "e"}}))
+		})
+
+		It("uses the TypeKey", func() {
+			c := config
+			c.TypeKey = "kind"
+
+			data, err := c.Marshal(log{Last: appCreated{GUID: "a"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(MatchJSON(`{"last":{"kind":"created","app":{"guid":"a"}},"events":null,"by_guid":null,"other":null}`))
+
+			var out log
+			Expect(c.Unmarshal(data, &out)).To(Succeed())
+			Expect(out.Last).To(Equal(appCreated{GUID: "a"}))
+		})
+
+		It("takes priority over a Union", func() {
+			c := config
+			c.Unions = []jsonry.Union{{Type: reflect.TypeFor[event](), Discriminator: "type", Variants: c.Types}}
+
+			data, err := c.Marshal(log{Last: appCreated{}, Other: appCreated{}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(MatchJSON(`{"last":{"$type":"created","app":{"guid":""}},"events":null,"by_guid":null,"other":{"type":"created","app":{"guid":""}}}`))
+		})
+
+		It("fails for a type without a name", func() {
+			_, err := config.Marshal(log{Events: []event{"text"}})
+			Expect(err).To(MatchError(`no discriminator for type "string" in union "jsonry_test.event" at index 0 (type "[]jsonry_test.event") path Events[0]`))
+
+			var out log
+			err = config.Unmarshal([]byte(`{"last":{"$type":"deleted"}}`), &out)
+			Expect(err).To(MatchError(`unknown discriminator "$type" value "deleted" for union "jsonry_test.event" at field "Last" (type "jsonry_test.event")`))
+		})
+
+		It("uses types that are added after the Config has been used", func() {
+			c := jsonry.Config{Types: map[string]reflect.Type{"created": reflect.TypeFor[appCreated]()}}
+			_, err := c.Marshal(log{Last: appCreated{}})
+			Expect(err).NotTo(HaveOccurred())
+
+			c.Types["renamed"] = reflect.TypeFor[appRenamed]()
+			data, err := c.Marshal(log{Last: appRenamed{To: "a"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(MatchJSON(`{"last":{"$type":"renamed","names":{"from":"","to":"a"}},"events":null,"by_guid":null,"other":null}`))
+		})
+
+		It("fails for a type that is not marshaled as a JSON object", func() {
+			c := config
+			c.Types = map[string]reflect.Type{"note": reflect.TypeFor[appNote]()}
+
+			_, err := c.Marshal(log{Last: appNote("hello")})
+			Expect(err).To(MatchError(`cannot write discriminator "$type" for type "jsonry_test.appNote" which is not a JSON object at field "Last" (type "jsonry_test.event")`))
+		})
+	})
+})
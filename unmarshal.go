@@ -1,105 +1,300 @@
 package jsonry
 
 import (
-	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
+	"strings"
+	"sync"
 
-	"code.cloudfoundry.org/jsonry/internal/path"
-	"code.cloudfoundry.org/jsonry/internal/tree"
+	"code.cloudfoundry.org/jsonry/internal/errorcontext"
+	"code.cloudfoundry.org/jsonry/internal/raw"
 )
 
 // Unmarshal parses the specified JSON into the specified Go struct receiver.
 // The receiver must be a pointer to a Go struct containing only fields of the type:
 // string, bool, int*, uint*, float*, map, slice or struct. JSONry is recursive.
+// The receiver may also be a pointer to a slice, map or basic type, so that a JSON array or scalar can
+// be unmarshaled, and any structs within it are unmarshaled using their JSONry paths.
+// Fields of type complex64 and complex128 can be unmarshaled by setting Config.ComplexEncoding.
 //
-// If a field implements the json.Unmarshaler interface, then the UnmarshalJSON() method will be called.
+// A []byte is unmarshaled from a base64 string in the same way as encoding/json, or from an array of numbers.
+// The tag options ",base64url" and ",hex" select URL-safe base64 and hexadecimal respectively.
+//
+// If a field implements the jsonry.JSONryUnmarshaler interface, then the UnmarshalJSONry() method will be called
+// with a Context describing the path of the field, the tag options, and the JSON object that contains the field.
+//
+// If a field implements the json.Unmarshaler interface, then the UnmarshalJSON() method will be called
+// with the JSON for the field exactly as it appears in the input.
+//
+// A json.RawMessage field receives the value at its path exactly as it appears in the input. Where the path
+// contains a list, the values are collected into a JSON array.
+//
+// A jsonry.Optional field is left unset when its path is not in the JSON, is set to null when the
+// JSON value is null, and otherwise holds the unmarshaled value.
+//
+// If a field implements the jsonry.Presence interface, then the SetPresent() method will be called to
+// report whether the path of the field was in the JSON, and whether the value was null.
+//
+// A field tagged ",remain" receives the keys of the JSON object for the struct that are not part of the path
+// of any other field. Keys of an object part way along a path are included within an object with the same
+// key, for example {"lifecycle":{"data":{}}} when another field has the path "lifecycle.type", but keys of
+// objects within an array part way along a path are not included. It is typically a map[string]interface{}
+// or map[string]json.RawMessage, so that unknown keys are preserved when the struct is marshaled again.
+//
+// An interface field is unmarshaled in the same way as encoding/json would unmarshal into an interface{},
+// unless there is a Union for the interface type in Config.Unions, in which case the discriminator in the
+// JSON selects the concrete type. An interface field tagged ",typed" is unmarshaled into the type from
+// Config.Types that has the name in the JSON.
+//
+// A default value for a field can be specified with the tag option ",default=" or with a separate "default" tag,
+// for example `jsonry:"lifecycle.type,default=buildpack"` or `default:"[\"a\",\"b\"]"`. When the path of the field
+// is not in the JSON, the default is unmarshaled into the field. A default that is valid JSON is unmarshaled
+// as JSON, and any other default is unmarshaled as a JSON string, as is the default for a string field.
+// Defaults are not used when Config.Merge is set, so that the existing values are kept.
+//
+// A JSON number that does not fit into the int*, uint* or float* field that receives it will result in an
+// error rather than being silently truncated.
 func Unmarshal(data []byte, receiver interface{}) error {
-	target := reflect.ValueOf(receiver)
+	return Config{}.Unmarshal(data, receiver)
+}
 
-	if target.Kind() != reflect.Ptr {
-		return errors.New("receiver must be a pointer to a struct, got a non-pointer")
+// Unmarshal works in the same way as the package-level Unmarshal() function, but using the
+// settings from the Config.
+func (c Config) Unmarshal(data []byte, receiver interface{}) error {
+	target, err := receiverTarget(receiver)
+	if err != nil {
+		return err
 	}
 
-	target = target.Elem()
-	if target.Kind() != reflect.Struct {
-		return fmt.Errorf("receiver must be a pointer to a struct type, got: %s", target.Type())
+	source, err := raw.Parse(data)
+	if err != nil {
+		return fmt.Errorf("error parsing JSON: %w", err)
 	}
 
-	var source map[string]interface{}
+	return c.unmarshalRoot(target, true, source)
+}
 
-	d := json.NewDecoder(bytes.NewBuffer(data))
-	d.UseNumber()
-	if err := d.Decode(&source); err != nil {
-		return fmt.Errorf("error parsing JSON: %w", err)
+func receiverTarget(receiver interface{}) (reflect.Value, error) {
+	target := reflect.ValueOf(receiver)
+
+	switch {
+	case target.Kind() != reflect.Ptr:
+		return reflect.Value{}, errors.New("receiver must be a pointer, got a non-pointer")
+	case target.IsNil():
+		return reflect.Value{}, fmt.Errorf("receiver must not be a nil pointer, got: %s", target.Type())
+	default:
+		return target.Elem(), nil
+	}
+}
+
+// unmarshalRoot sets the value that the receiver passed to Unmarshal() points to, where a struct
+// is always set using its JSONry paths
+func (c *Config) unmarshalRoot(target reflect.Value, found bool, source raw.Value) error {
+	if c.Naming != nil {
+		c.readPlans = make(map[reflect.Type]*readPlan)
 	}
 
-	return unmarshalIntoStruct(target, true, source)
+	if target.Kind() == reflect.Struct {
+		return c.unmarshalIntoStruct(target, found, source, nil)
+	}
+
+	return c.unmarshal(target, found, source, tagOptions{})
 }
 
-func unmarshalIntoStruct(target reflect.Value, found bool, source interface{}) error {
-	if !found || source == nil {
+// location records where a value is being unmarshaled, so that a Coercion can report its path.
+// It is only recorded when there is an OnCoercion callback.
+type location struct {
+	parent *location
+	sort   locationSort
+	name   string
+	index  int
+	typ    reflect.Type
+}
+
+type locationSort uint
+
+const (
+	fieldLocation locationSort = iota
+	indexLocation
+	keyLocation
+)
+
+func (c *Config) locate(parent *location, l location) *location {
+	if c.OnCoercion == nil {
 		return nil
 	}
 
-	src, ok := source.(map[string]interface{})
-	if !ok {
-		return newConversionError(source)
+	r := l
+	r.parent = parent
+	return &r
+}
+
+func (l *location) path() string {
+	var ctx errorcontext.ErrorContext
+	for ; l != nil; l = l.parent {
+		switch l.sort {
+		case fieldLocation:
+			ctx = ctx.WithField(l.name, l.typ)
+		case indexLocation:
+			ctx = ctx.WithIndex(l.index, l.typ)
+		case keyLocation:
+			ctx = ctx.WithKey(l.name, l.typ)
+		}
 	}
+	return ctx.Path()
+}
 
-	target = allocateIfNeeded(target)
+func (c *Config) unmarshal(target reflect.Value, found bool, source raw.Value, opts tagOptions) error {
+	d := decodingFor(target.Type())
 
-	for i := 0; i < target.NumField(); i++ {
-		field := target.Type().Field(i)
+	err := c.unmarshalValue(d, target, found, source, opts)
+	if err == nil && d.presence {
+		setPresence(target, found, source)
+	}
+	return err
+}
 
-		if public(field) {
-			p := path.ComputePath(field)
-			s, found := tree.Tree(src).Fetch(p)
-			if err := unmarshal(target.Field(i), found, s); err != nil {
-				return wrapErrorWithFieldContext(err, field.Name, field.Type)
-			}
-		}
+func (c *Config) unmarshalValue(d decoding, target reflect.Value, found bool, source raw.Value, opts tagOptions) error {
+	if codec, ok := c.codecFor(underlyingType(target)); ok {
+		return c.unmarshalWithCodec(codec, target, found, source)
 	}
 
-	return nil
+	if union, ok := c.unionFor(underlyingType(target), opts); ok {
+		return c.unmarshalIntoUnion(union, target, found, source, opts)
+	}
+
+	switch d.sort {
+	case jsonryUnmarshalerDecoding:
+		return c.unmarshalIntoJSONryUnmarshaler(target, found, source, opts)
+	case rawMessageDecoding:
+		return c.unmarshalIntoRawMessage(target, found, source)
+	case optionalDecoding:
+		return c.unmarshalIntoOptional(target, found, source, opts)
+	case jsonUnmarshalerDecoding:
+		return unmarshalIntoJSONUnmarshaler(target, found, source)
+	case leafDecoding:
+		return c.unmarshalInfoLeaf(target, found, source, opts.at)
+	case complexDecoding:
+		return c.unmarshalIntoComplex(target, found, source)
+	case structDecoding:
+		return c.unmarshalIntoStruct(target, found, source, opts.at)
+	case sliceDecoding:
+		return c.unmarshalIntoSlice(target, found, source, opts)
+	case mapDecoding:
+		return c.unmarshalIntoMap(target, found, source, opts)
+	default:
+		return newUnsupportedTypeError(target.Type())
+	}
 }
 
-func unmarshal(target reflect.Value, found bool, source interface{}) error {
-	kind := underlyingType(target).Kind()
+// decoding records how values of a type are unmarshaled, which only depends on the type,
+// so that the interfaces that the type implements are only inspected once
+type decoding struct {
+	sort     decodingSort
+	presence bool
+}
 
-	var err error
-	switch {
-	case reflect.PointerTo(target.Type()).Implements(reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()):
-		err = unmarshalIntoJSONUnmarshaler(target, found, source)
+type decodingSort uint
+
+const (
+	unsupportedDecoding decodingSort = iota
+	jsonryUnmarshalerDecoding
+	rawMessageDecoding
+	optionalDecoding
+	jsonUnmarshalerDecoding
+	leafDecoding
+	complexDecoding
+	structDecoding
+	sliceDecoding
+	mapDecoding
+)
+
+// decodings are shared between calls to Unmarshal
+var decodings sync.Map
+
+func decodingFor(t reflect.Type) decoding {
+	if d, ok := decodings.Load(t); ok {
+		return d.(decoding)
+	}
+
+	d := newDecoding(t)
+	decodings.Store(t, d)
+	return d
+}
+
+func newDecoding(t reflect.Type) decoding {
+	u := t
+	if t.Kind() == reflect.Ptr {
+		u = t.Elem()
+	}
+
+	d := decoding{presence: reflect.PointerTo(u).Implements(presenceType)}
+	switch kind := u.Kind(); {
+	case reflect.PointerTo(u).Implements(jsonryUnmarshalerType):
+		d.sort = jsonryUnmarshalerDecoding
+	case u == rawMessageType:
+		d.sort = rawMessageDecoding
+	case reflect.PointerTo(u).Implements(optionalSetterType):
+		d.sort = optionalDecoding
+	case reflect.PointerTo(t).Implements(reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()):
+		d.sort = jsonUnmarshalerDecoding
 	case basicType(kind), kind == reflect.Interface:
-		err = unmarshalInfoLeaf(target, found, source)
+		d.sort = leafDecoding
+	case complexType(kind):
+		d.sort = complexDecoding
 	case kind == reflect.Struct:
-		err = unmarshalIntoStruct(target, found, source)
+		d.sort = structDecoding
 	case kind == reflect.Slice:
-		err = unmarshalIntoSlice(target, found, source)
+		d.sort = sliceDecoding
 	case kind == reflect.Map:
-		err = unmarshalIntoMap(target, found, source)
-	default:
-		err = newUnsupportedTypeError(target.Type())
+		d.sort = mapDecoding
+	}
+	return d
+}
+
+func setPresence(target reflect.Value, found bool, source raw.Value) {
+	if target.Kind() == reflect.Ptr {
+		if target.IsNil() {
+			return
+		}
+		target = target.Elem()
+	}
+
+	if target.CanAddr() {
+		target.Addr().Interface().(Presence).SetPresent(found, found && source.IsNull())
 	}
-	return err
 }
 
-func unmarshalInfoLeaf(target reflect.Value, found bool, source interface{}) error {
+func (c *Config) unmarshalInfoLeaf(target reflect.Value, found bool, source raw.Value, at *location) error {
 	if !found {
 		return nil
 	}
 
+	v, err := decodeSource(source)
+	if err != nil {
+		return err
+	}
+	return c.setLeaf(target, v, at)
+}
+
+func (c *Config) setLeaf(target reflect.Value, source interface{}, at *location) error {
+	if target.Kind() != reflect.Ptr {
+		if r, ok := c.coerce(target.Type(), source); ok {
+			return c.setCoerced(target, source, r, at)
+		}
+	}
+
 	switch target.Kind() {
 	case reflect.Ptr:
 		switch source {
 		case nil:
 			return setZeroValue(target)
 		default:
-			return unmarshalInfoLeaf(allocateIfNeeded(target), found, source)
+			return c.setLeaf(c.allocateIfNeeded(target), source, at)
 		}
 	case reflect.String:
 		switch s := source.(type) {
@@ -120,30 +315,21 @@ func unmarshalInfoLeaf(target reflect.Value, found bool, source interface{}) err
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		switch n := source.(type) {
 		case json.Number:
-			if i, err := strconv.ParseInt(n.String(), 10, 64); err == nil {
-				target.SetInt(i)
-				return nil
-			}
+			return c.setInt(target, n)
 		case nil:
 			return nil
 		}
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		switch n := source.(type) {
 		case json.Number:
-			if i, err := strconv.ParseUint(n.String(), 10, 64); err == nil {
-				target.SetUint(i)
-				return nil
-			}
+			return c.setUint(target, n)
 		case nil:
 			return nil
 		}
 	case reflect.Float32, reflect.Float64:
 		switch n := source.(type) {
 		case json.Number:
-			if f, err := strconv.ParseFloat(n.String(), 64); err == nil {
-				target.SetFloat(f)
-				return nil
-			}
+			return setFloat(target, n)
 		case nil:
 			return nil
 		}
@@ -152,7 +338,7 @@ func unmarshalInfoLeaf(target reflect.Value, found bool, source interface{}) err
 		case nil:
 			return setZeroValue(target)
 		default:
-			target.Set(reflect.ValueOf(convertNumbers(source)))
+			target.Set(reflect.ValueOf(c.convertNumbers(source)))
 		}
 		return nil
 	}
@@ -160,72 +346,261 @@ func unmarshalInfoLeaf(target reflect.Value, found bool, source interface{}) err
 	return newConversionError(source)
 }
 
-func unmarshalIntoSlice(target reflect.Value, found bool, source interface{}) error {
-	if !found || source == nil {
-		return nil
+// coerce converts a JSON value into one that can be set into the type in Lenient mode,
+// and reports whether it did
+func (c *Config) coerce(t reflect.Type, source interface{}) (interface{}, bool) {
+	if !c.Lenient {
+		return nil, false
 	}
 
-	src, ok := source.([]interface{})
-	if !ok {
+	switch s := source.(type) {
+	case string:
+		switch t.Kind() {
+		case reflect.Bool:
+			// strconv.ParseBool() would also accept values such as "1" and "T", which are not JSON
+			switch s {
+			case "true":
+				return true, true
+			case "false":
+				return false, true
+			}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			if n, ok := parseNumber(s); ok {
+				return n, true
+			}
+		}
+	case json.Number:
+		if t.Kind() == reflect.String {
+			return s.String(), true
+		}
+	}
+
+	return nil, false
+}
+
+// setCoerced sets a coerced value, and only reports the coercion once the value has been set.
+// Errors describe the value in the JSON rather than the coerced value.
+func (c *Config) setCoerced(target reflect.Value, source, coerced interface{}, at *location) error {
+	if err := c.setLeaf(target, coerced, at); err != nil {
+		var r *rangeError
+		if errors.As(err, &r) {
+			return newRangeError(source)
+		}
 		return newConversionError(source)
 	}
 
+	c.reportCoercion(source, target.Type(), at)
+	return nil
+}
+
+func (c *Config) reportCoercion(source interface{}, t reflect.Type, at *location) {
+	if c.OnCoercion != nil {
+		c.OnCoercion(Coercion{Value: source, Type: t, Path: at.path()})
+	}
+}
+
+// parseNumber parses a string which contains exactly one JSON number, without surrounding whitespace
+func parseNumber(s string) (json.Number, bool) {
+	if s != strings.TrimSpace(s) {
+		return "", false
+	}
+
+	d := json.NewDecoder(strings.NewReader(s))
+	d.UseNumber()
+
+	var v interface{}
+	if err := d.Decode(&v); err != nil || d.InputOffset() != int64(len(s)) {
+		return "", false
+	}
+
+	n, ok := v.(json.Number)
+	return n, ok
+}
+
+func (c *Config) setInt(target reflect.Value, n json.Number) error {
+	i, err := strconv.ParseInt(n.String(), 10, 64)
+	switch {
+	case err == nil:
+	case errors.Is(err, strconv.ErrRange):
+		return newRangeError(n)
+	case c.AllowIntegralFloats:
+		f, err := parseIntegral(n)
+		if err != nil {
+			return err
+		}
+		if f < math.MinInt64 || f >= -math.MinInt64 {
+			return newRangeError(n)
+		}
+		i = int64(f)
+	default:
+		return newConversionError(n)
+	}
+
+	if target.OverflowInt(i) {
+		return newRangeError(n)
+	}
+
+	target.SetInt(i)
+	return nil
+}
+
+func (c *Config) setUint(target reflect.Value, n json.Number) error {
+	u, err := strconv.ParseUint(n.String(), 10, 64)
+	switch {
+	case err == nil:
+	case errors.Is(err, strconv.ErrRange), isNegativeInteger(n):
+		return newRangeError(n)
+	case c.AllowIntegralFloats:
+		f, err := parseIntegral(n)
+		if err != nil {
+			return err
+		}
+		if f < 0 || f >= 2*-math.MinInt64 {
+			return newRangeError(n)
+		}
+		u = uint64(f)
+	default:
+		return newConversionError(n)
+	}
+
+	if target.OverflowUint(u) {
+		return newRangeError(n)
+	}
+
+	target.SetUint(u)
+	return nil
+}
+
+func setFloat(target reflect.Value, n json.Number) error {
+	f, err := strconv.ParseFloat(n.String(), 64)
+	switch {
+	case err == nil:
+	case errors.Is(err, strconv.ErrRange):
+		return newRangeError(n)
+	default:
+		return newConversionError(n)
+	}
+
+	if target.OverflowFloat(f) {
+		return newRangeError(n)
+	}
+
+	target.SetFloat(f)
+	return nil
+}
+
+func parseIntegral(n json.Number) (float64, error) {
+	f, err := strconv.ParseFloat(n.String(), 64)
+	switch {
+	case errors.Is(err, strconv.ErrRange):
+		return 0, newRangeError(n)
+	case err != nil, f != math.Trunc(f):
+		return 0, newConversionError(n)
+	default:
+		return f, nil
+	}
+}
+
+func isNegativeInteger(n json.Number) bool {
+	i, err := strconv.ParseInt(n.String(), 10, 64)
+	return (err == nil && i < 0) || (errors.Is(err, strconv.ErrRange) && i == math.MinInt64)
+}
+
+func (c *Config) unmarshalIntoSlice(target reflect.Value, found bool, source raw.Value, opts tagOptions) error {
+	if !found || source.IsNull() {
+		return nil
+	}
+
+	if source[0] == '"' && byteSlice(underlyingType(target)) {
+		s, err := decodeSource(source)
+		if err != nil {
+			return err
+		}
+		return c.unmarshalBytes(target, s.(string), opts)
+	}
+
+	var coerced interface{}
+	src, ok := source.Array()
+	switch {
+	case ok:
+	case c.Lenient:
+		v, err := decodeSource(source)
+		if err != nil {
+			return err
+		}
+		coerced = v
+		src = []raw.Value{source}
+	default:
+		return newSourceConversionError(source)
+	}
+
 	slice := reflect.MakeSlice(underlyingType(target), len(src), len(src))
-	allocateIfNeeded(target).Set(slice)
+	c.allocateIfNeeded(target).Set(slice)
 
 	for i := range src {
 		elem := slice.Index(i)
-		if err := unmarshal(elem, true, src[i]); err != nil {
+		elemOpts := opts.element()
+		elemOpts.at = c.locate(opts.at, location{sort: indexLocation, index: i, typ: elem.Type()})
+		if err := c.unmarshal(elem, true, src[i], elemOpts); err != nil {
 			return wrapErrorWithIndexContext(err, i, elem.Type())
 		}
 	}
 
+	if coerced != nil {
+		c.reportCoercion(coerced, target.Type(), opts.at)
+	}
 	return nil
 }
 
-func unmarshalIntoMap(target reflect.Value, found bool, source interface{}) error {
+func (c *Config) unmarshalIntoMap(target reflect.Value, found bool, source raw.Value, opts tagOptions) error {
 	targetType := underlyingType(target)
 
 	if targetType.Key().Kind() != reflect.String {
 		return newUnsupportedKeyTypeError(targetType.Key())
 	}
 
-	if !found || source == nil {
+	if !found || source.IsNull() {
 		return nil
 	}
 
-	src, ok := source.(map[string]interface{})
+	src, ok := source.Object()
 	if !ok {
-		return newConversionError(source)
+		return newSourceConversionError(source)
 	}
 
-	m := reflect.MakeMap(targetType)
-	allocateIfNeeded(target).Set(m)
+	m := c.allocateIfNeeded(target)
+	if !c.Merge || m.IsNil() {
+		m.Set(reflect.MakeMap(targetType))
+	}
 
 	for k, v := range src {
+		key := reflect.ValueOf(k).Convert(targetType.Key())
 		targetValue := reflect.New(targetType.Elem()).Elem()
-		if err := unmarshal(targetValue, true, v); err != nil {
+		if existing := m.MapIndex(key); c.Merge && existing.IsValid() {
+			targetValue.Set(existing)
+		}
+
+		elemOpts := opts.element()
+		elemOpts.at = c.locate(opts.at, location{sort: keyLocation, name: k, typ: targetValue.Type()})
+		if err := c.unmarshal(targetValue, true, v, elemOpts); err != nil {
 			return wrapErrorWithKeyContext(err, k, targetValue.Type())
 		}
 
-		m.SetMapIndex(reflect.ValueOf(k).Convert(targetType.Key()), targetValue)
+		m.SetMapIndex(key, targetValue)
 	}
 
 	return nil
 }
 
-func unmarshalIntoJSONUnmarshaler(target reflect.Value, found bool, source interface{}) error {
+func unmarshalIntoJSONUnmarshaler(target reflect.Value, found bool, source raw.Value) error {
 	if !found {
 		return nil
 	}
 
-	json, err := json.Marshal(source)
-	if err != nil {
-		return fmt.Errorf("error creating JSON for UnmarshalJSON(): %w", err)
-	}
-
 	elem := reflect.New(target.Type())
-	s := elem.MethodByName("UnmarshalJSON").Call([]reflect.Value{reflect.ValueOf(json)})
+	s := elem.MethodByName("UnmarshalJSON").Call([]reflect.Value{reflect.ValueOf([]byte(source))})
 
 	if err := checkForError(s[0]); err != nil {
 		return newForeignError("error from UnmarshalJSON() call", err)
@@ -235,24 +610,51 @@ func unmarshalIntoJSONUnmarshaler(target reflect.Value, found bool, source inter
 	return nil
 }
 
+// decodeSource decodes the JSON into a Go value, reporting an error when the JSON is not valid
+func decodeSource(source raw.Value) (interface{}, error) {
+	v, err := source.Interface()
+	if err != nil {
+		return nil, newForeignError("error decoding JSON", err)
+	}
+	return v, nil
+}
+
+func (c *Config) unmarshalIntoRawMessage(target reflect.Value, found bool, source raw.Value) error {
+	if !found {
+		return nil
+	}
+
+	if target.Kind() == reflect.Ptr && source.IsNull() {
+		return setZeroValue(target)
+	}
+
+	r := reflect.ValueOf(append(json.RawMessage(nil), source...))
+	c.allocateIfNeeded(target).Set(r)
+	return nil
+}
+
 func setZeroValue(target reflect.Value) error {
 	target.Set(reflect.Zero(target.Type()))
 	return nil
 }
 
-func allocateIfNeeded(target reflect.Value) reflect.Value {
+func (c *Config) allocateIfNeeded(target reflect.Value) reflect.Value {
 	if target.Kind() != reflect.Ptr {
 		return target
 	}
 
+	if c.Merge && !target.IsNil() {
+		return target.Elem()
+	}
+
 	n := reflect.New(target.Type().Elem())
 	target.Set(n)
 	return n.Elem()
 }
 
-func convertNumbers(input interface{}) interface{} {
+func (c *Config) convertNumbers(input interface{}) interface{} {
 	n, ok := input.(json.Number)
-	if !ok {
+	if !ok || c.UseNumber {
 		return input
 	}
 
@@ -3,6 +3,7 @@ package jsonry_test
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 
 	"code.cloudfoundry.org/jsonry"
 	. "github.com/onsi/ginkgo/v2"
@@ -174,6 +175,76 @@ var _ = Describe("Unmarshal", func() {
 			expectToFail(&s, `{"A":"foo"}`, `cannot unmarshal "foo" type "string" into field "A" (type "float64")`)
 		})
 
+		Context("numbers that are out of range", func() {
+			It("rejects numbers that overflow sized int fields", func() {
+				var s struct {
+					I8  int8
+					I16 int16
+					I32 int32
+					I64 int64
+				}
+				unmarshal(&s, `{"I8":127,"I16":-32768,"I32":2147483647,"I64":-9223372036854775808}`)
+				Expect(s.I8).To(Equal(int8(127)))
+				Expect(s.I16).To(Equal(int16(-32768)))
+				Expect(s.I32).To(Equal(int32(2147483647)))
+				Expect(s.I64).To(Equal(int64(-9223372036854775808)))
+
+				expectToFail(&s, `{"I8":300}`, `number "300" is out of range for field "I8" (type "int8")`)
+				expectToFail(&s, `{"I16":-32769}`, `number "-32769" is out of range for field "I16" (type "int16")`)
+				expectToFail(&s, `{"I32":2147483648}`, `number "2147483648" is out of range for field "I32" (type "int32")`)
+				expectToFail(&s, `{"I64":9223372036854775808}`, `number "9223372036854775808" is out of range for field "I64" (type "int64")`)
+			})
+
+			It("rejects numbers that overflow sized uint fields", func() {
+				var s struct {
+					U8  uint8
+					U64 uint64
+				}
+				unmarshal(&s, `{"U8":255,"U64":18446744073709551615}`)
+				Expect(s.U8).To(Equal(uint8(255)))
+				Expect(s.U64).To(Equal(uint64(18446744073709551615)))
+
+				expectToFail(&s, `{"U8":256}`, `number "256" is out of range for field "U8" (type "uint8")`)
+				expectToFail(&s, `{"U8":-1}`, `number "-1" is out of range for field "U8" (type "uint8")`)
+				expectToFail(&s, `{"U64":18446744073709551616}`, `number "18446744073709551616" is out of range for field "U64" (type "uint64")`)
+				expectToFail(&s, `{"U64":-99999999999999999999}`, `number "-99999999999999999999" is out of range for field "U64" (type "uint64")`)
+			})
+
+			It("rejects numbers that overflow float fields", func() {
+				var s struct {
+					F32 float32
+					F64 float64
+				}
+				unmarshal(&s, `{"F32":3.4e38,"F64":1.7e308}`)
+				Expect(s.F32).To(Equal(float32(3.4e38)))
+				Expect(s.F64).To(Equal(1.7e308))
+
+				expectToFail(&s, `{"F32":3.5e38}`, `number "3.5e38" is out of range for field "F32" (type "float32")`)
+				expectToFail(&s, `{"F64":1.8e308}`, `number "1.8e308" is out of range for field "F64" (type "float64")`)
+			})
+
+			It("reports the path to the number", func() {
+				var s struct {
+					L []struct{ I int8 } `jsonry:"a.b"`
+				}
+				expectToFail(&s, `{"a":{"b":[{"I":1},{"I":1000}]}}`, `number "1000" is out of range for field "I" (type "int8") path L[1].I`)
+			})
+
+			It("rejects numbers that overflow pointers to sized types", func() {
+				var s struct{ P *int8 }
+				expectToFail(&s, `{"P":128}`, `number "128" is out of range for field "P" (type "*int8")`)
+			})
+		})
+
+		It("rejects a float for an int field", func() {
+			var s struct {
+				I int
+				U uint
+			}
+			expectToFail(&s, `{"I":3.0}`, `cannot unmarshal "3.0" type "number" into field "I" (type "int")`)
+			expectToFail(&s, `{"U":4e2}`, `cannot unmarshal "4e2" type "number" into field "U" (type "uint")`)
+		})
+
 		It("rejects a complex64 field", func() {
 			var s struct{ C complex64 }
 			expectToFail(&s, `{}`, `unsupported type "complex64" at field "C" (type "complex64")`)
@@ -311,6 +382,70 @@ var _ = Describe("Unmarshal", func() {
 			})
 		})
 
+		Context("byte slices", func() {
+			It("unmarshals a []byte from base64", func() {
+				var s struct {
+					B []byte
+					P *[]byte
+				}
+				unmarshal(&s, `{"B":"aGVsbG//","P":"aGk="}`)
+				Expect(s.B).To(Equal([]byte("hello\xff")))
+				Expect(s.P).To(PointTo(Equal([]byte("hi"))))
+
+				expectToFail(&s, `{"B":"not base64!"}`, `cannot unmarshal "not base64!" type "string" into field "B" (type "[]uint8")`)
+			})
+
+			It("unmarshals a []byte from an array of numbers", func() {
+				var s struct{ B []byte }
+				unmarshal(&s, `{"B":[104,105]}`)
+				Expect(s.B).To(Equal([]byte("hi")))
+			})
+
+			It("unmarshals a []byte from URL-safe base64", func() {
+				var s struct {
+					B []byte `jsonry:"b,base64url"`
+					C []byte `jsonry:"c,base64url"`
+				}
+				unmarshal(&s, `{"b":"aGVsbG__","c":"aGk"}`)
+				Expect(s.B).To(Equal([]byte("hello\xff")))
+				Expect(s.C).To(Equal([]byte("hi")))
+			})
+
+			It("unmarshals a []byte from hex", func() {
+				var s struct {
+					B []byte            `jsonry:"a.b,hex"`
+					L [][]byte          `jsonry:",hex"`
+					M map[string][]byte `jsonry:",hex"`
+				}
+				unmarshal(&s, `{"a":{"b":"deadbeef"},"L":["01","0203"],"M":{"a":"ff"}}`)
+				Expect(s.B).To(Equal([]byte{0xde, 0xad, 0xbe, 0xef}))
+				Expect(s.L).To(Equal([][]byte{{1}, {2, 3}}))
+				Expect(s.M).To(Equal(map[string][]byte{"a": {0xff}}))
+
+				expectToFail(&s, `{"L":["0x"]}`, `cannot unmarshal "0x" type "string" into index 0 (type "[]uint8") path L[0]`)
+			})
+
+			It("unmarshals named byte slice types", func() {
+				type bytes []byte
+				var s struct{ B bytes }
+				unmarshal(&s, `{"B":"aGk="}`)
+				Expect(s.B).To(Equal(bytes("hi")))
+			})
+
+			It("unmarshals slices with a named byte element type", func() {
+				type myByte byte
+				var s struct {
+					B []myByte
+					P *[]myByte
+					H []myByte `jsonry:",hex"`
+				}
+				unmarshal(&s, `{"B":"AQI=","P":"aGk=","H":"ff00"}`)
+				Expect(s.B).To(Equal([]myByte{1, 2}))
+				Expect(s.P).To(PointTo(Equal([]myByte("hi"))))
+				Expect(s.H).To(Equal([]myByte{0xff, 0}))
+			})
+		})
+
 		Context("maps", func() {
 			It("unmarshals maps with interface values", func() {
 				By("map", func() {
@@ -422,6 +557,84 @@ var _ = Describe("Unmarshal", func() {
 			expectToFail(&s, `{"S":"fail"}`, `error from UnmarshalJSON() call at field "S" (type "jsonry_test.implementsJSONUnmarshaler"): ouch`)
 		})
 
+		It("passes the input to a json.Unmarshaler exactly as it appears", func() {
+			var s struct {
+				R recordsJSON   `jsonry:"a.b"`
+				L []recordsJSON `jsonry:"a.c"`
+			}
+			unmarshal(&s, `{"a": {"b": { "z": 1, "a": 12345678901234567890 }, "c": [ 1.50 , "\u00e9" ]}}`)
+			Expect(string(s.R.input)).To(Equal(`{ "z": 1, "a": 12345678901234567890 }`))
+			Expect(s.L).To(HaveLen(2))
+			Expect(string(s.L[0].input)).To(Equal(`1.50`))
+			Expect(string(s.L[1].input)).To(Equal(`"\u00e9"`))
+		})
+
+		It("unmarshals into a JSONryUnmarshaler with a context", func() {
+			var s struct {
+				R relationship                  `jsonry:"space,omitempty"`
+				P *relationship                 `jsonry:"org"`
+				N jsonry.Optional[relationship] `jsonry:"relationships.app.data"`
+				M relationship                  `jsonry:"missing"`
+				L []relationship
+			}
+			unmarshal(&s, `{
+				"space":"space-guid","space_type":"space",
+				"org":"org-guid","org_type":"organization",
+				"relationships":{"app":{"data":"app-guid","data_type":"app"}},
+				"L":["other-guid"]
+			}`)
+			Expect(s.R).To(Equal(relationship{GUID: "space-guid", Type: "space", Options: []string{"omitempty"}}))
+			Expect(s.P).To(PointTo(Equal(relationship{GUID: "org-guid", Type: "organization"})))
+			Expect(s.N).To(Equal(jsonry.Some(relationship{GUID: "app-guid", Type: "app"})))
+			Expect(s.M).To(Equal(relationship{}))
+			Expect(s.L).To(Equal([]relationship{{GUID: "other-guid"}}))
+
+			expectToFail(&s, `{"space":42}`, `error from UnmarshalJSONry() call at field "R" (type "jsonry_test.relationship"): json: cannot unmarshal number into Go value of type string`)
+		})
+
+		It("decodes the JSON object for a Context once for each struct", func() {
+			var s struct {
+				A recordsParent `jsonry:"a"`
+				B recordsParent `jsonry:"b"`
+				C recordsParent `jsonry:"c.d"`
+			}
+			unmarshal(&s, `{"a":1,"b":2,"c":{"d":3}}`)
+			Expect(s.A.parent).To(Equal(map[string]interface{}{"a": json.Number("1"), "b": json.Number("2"), "c": map[string]interface{}{"d": json.Number("3")}}))
+			Expect(reflect.ValueOf(s.B.parent).Pointer()).To(Equal(reflect.ValueOf(s.A.parent).Pointer()))
+			Expect(s.C.parent).To(Equal(map[string]interface{}{"d": json.Number("3")}))
+		})
+
+		It("unmarshals into a json.RawMessage field exactly as it appears in the input", func() {
+			var s struct {
+				R json.RawMessage  `jsonry:"a.b"`
+				P *json.RawMessage `jsonry:"a.c"`
+				S json.RawMessage  `jsonry:"a.d"`
+				N json.RawMessage
+				Q *json.RawMessage
+			}
+			s.Q = new(json.RawMessage)
+			unmarshal(&s, `{"a": {"b": {"z": 1,  "a":[1.50e1, "\u00e9"]}, "c": 42.0, "d": "x"}, "N": null, "Q": null}`)
+			Expect(string(s.R)).To(Equal(`{"z": 1,  "a":[1.50e1, "\u00e9"]}`))
+			Expect(s.P).To(PointTo(Equal(json.RawMessage(`42.0`))))
+			Expect(string(s.S)).To(Equal(`"x"`))
+			Expect(string(s.N)).To(Equal(`null`))
+			Expect(s.Q).To(BeNil())
+		})
+
+		It("unmarshals lists of json.RawMessage", func() {
+			var s struct {
+				L []json.RawMessage `jsonry:"items"`
+				M json.RawMessage   `jsonry:"items.data"`
+			}
+			unmarshal(&s, `{"items": [{"data": {"b":2, "a":1}}, {}, {"data": [ 1 ]}]}`)
+			Expect(s.L).To(Equal([]json.RawMessage{
+				json.RawMessage(`{"data": {"b":2, "a":1}}`),
+				json.RawMessage(`{}`),
+				json.RawMessage(`{"data": [ 1 ]}`),
+			}))
+			Expect(string(s.M)).To(Equal(`[{"b":2, "a":1},null,[ 1 ]]`))
+		})
+
 		It("unmarshals into named types and type aliases", func() {
 			type alias = string
 			type named string
@@ -488,6 +701,54 @@ var _ = Describe("Unmarshal", func() {
 		})
 	})
 
+	Describe("reading the JSON", func() {
+		It("reads fields which share a path prefix", func() {
+			var s struct {
+				A string   `jsonry:"a.b.c"`
+				B string   `jsonry:"a.b.d"`
+				C string   `jsonry:"a.e"`
+				D []string `jsonry:"a.f.g"`
+				E string   `jsonry:"h"`
+				F []int    `jsonry:"a.f.i"`
+			}
+			unmarshal(&s, `{"x":{"y":[1,{"z":2}]},"a":{"b":{"d":"bar","c":"foo"},"f":[{"g":"g1","i":1},{"g":"g2"}],"e":"baz"},"h":"quz"}`)
+			Expect(s.A).To(Equal("foo"))
+			Expect(s.B).To(Equal("bar"))
+			Expect(s.C).To(Equal("baz"))
+			Expect(s.D).To(Equal([]string{"g1", "g2"}))
+			Expect(s.E).To(Equal("quz"))
+			Expect(s.F).To(Equal([]int{1, 0}))
+		})
+
+		It("reads a field which is also a path prefix of another field", func() {
+			var s struct {
+				A map[string]interface{} `jsonry:"a"`
+				B string                 `jsonry:"a.b"`
+			}
+			unmarshal(&s, `{"a":{"b":"foo"}}`)
+			Expect(s.A).To(Equal(map[string]interface{}{"b": "foo"}))
+			Expect(s.B).To(Equal("foo"))
+		})
+
+		It("uses the last value when a key is repeated", func() {
+			var s struct {
+				A string `jsonry:"a.b"`
+				C string `jsonry:"c"`
+			}
+			unmarshal(&s, `{"a":{"b":"first"},"c":"first","a":{"b":"second"},"c":"second"}`)
+			Expect(s.A).To(Equal("second"))
+			Expect(s.C).To(Equal("second"))
+		})
+
+		It("reads keys containing escapes", func() {
+			var s struct {
+				A string `json:"é"`
+			}
+			unmarshal(&s, `{"\u00e9":"foo"}`)
+			Expect(s.A).To(Equal("foo"))
+		})
+	})
+
 	Describe("recursive composition", func() {
 		It("unmarshals into a struct field", func() {
 			type t struct{ S string }
@@ -526,6 +787,435 @@ var _ = Describe("Unmarshal", func() {
 		})
 	})
 
+	Describe("remain", func() {
+		It("receives the keys that no other field uses", func() {
+			var s struct {
+				Name  string                 `jsonry:"name"`
+				GUID  string                 `jsonry:"metadata.guid"`
+				Other map[string]interface{} `jsonry:",remain"`
+			}
+			unmarshal(&s, `{"name":"foo","metadata":{"guid":"bar","labels":{}},"state":"STARTED","links":{"self":"baz"}}`)
+			Expect(s.Name).To(Equal("foo"))
+			Expect(s.GUID).To(Equal("bar"))
+			Expect(s.Other).To(Equal(map[string]interface{}{
+				"state":    "STARTED",
+				"links":    map[string]interface{}{"self": "baz"},
+				"metadata": map[string]interface{}{"labels": map[string]interface{}{}},
+			}))
+		})
+
+		It("preserves unknown keys in nested objects across a read-modify-write cycle", func() {
+			var s struct {
+				Type   string                     `jsonry:"lifecycle.type"`
+				Stacks []string                   `jsonry:"lifecycle.data.stacks"`
+				Names  []string                   `jsonry:"apps[].name"`
+				Other  map[string]json.RawMessage `jsonry:",remain"`
+			}
+			unmarshal(&s, `{"lifecycle":{"type":"buildpack","unknown":true,"data":{"stacks":["s"],"more":1}},"apps":[{"name":"a","guid":"g"}]}`)
+			Expect(s.Other).To(Equal(map[string]json.RawMessage{"lifecycle": json.RawMessage(`{"data":{"more":1},"unknown":true}`)}))
+			s.Type = "docker"
+
+			out, err := jsonry.Marshal(s)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(MatchJSON(`{"lifecycle":{"type":"docker","unknown":true,"data":{"stacks":["s"],"more":1}},"apps":[{"name":"a"}]}`))
+		})
+
+		It("preserves unknown keys across a read-modify-write cycle", func() {
+			var s struct {
+				Name  string                     `jsonry:"name"`
+				Other map[string]json.RawMessage `jsonry:",remain"`
+			}
+			unmarshal(&s, `{"name":"foo","future":{"b": 1.50, "a": [ ]}}`)
+			s.Name = "bar"
+
+			out, err := jsonry.Marshal(s)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(out)).To(Equal(`{"future":{"b":1.50,"a":[]},"name":"bar"}`))
+		})
+
+		It("is left as nil when there are no other keys", func() {
+			var s struct {
+				Name  string                 `jsonry:"name"`
+				Other map[string]interface{} `jsonry:",remain"`
+			}
+			unmarshal(&s, `{"name":"foo"}`)
+			Expect(s.Other).To(BeNil())
+		})
+
+		It("works in nested structs", func() {
+			type inner struct {
+				A     int
+				Other map[string]int `jsonry:",remain"`
+			}
+			var s struct {
+				I inner `jsonry:"i"`
+			}
+			unmarshal(&s, `{"i":{"A":1,"B":2,"C":3}}`)
+			Expect(s.I).To(Equal(inner{A: 1, Other: map[string]int{"B": 2, "C": 3}}))
+
+			expectToFail(&s, `{"i":{"B":"x"}}`, `cannot unmarshal "x" type "string" into key "B" (type "int") path I.Other["B"]`)
+		})
+	})
+
+	Describe("Presence interface", func() {
+		It("records whether the path was present", func() {
+			var s struct {
+				A recordsPresence `jsonry:"a.b"`
+				B recordsPresence `jsonry:"a.c"`
+				C recordsPresence `jsonry:"a.d"`
+			}
+			unmarshal(&s, `{"a":{"b":{"name":"foo"},"c":null}}`)
+			Expect(s.A.Name).To(Equal("foo"))
+			Expect(s.A.found).To(BeTrue())
+			Expect(s.A.null).To(BeFalse())
+			Expect(s.B.found).To(BeTrue())
+			Expect(s.B.null).To(BeTrue())
+			Expect(s.C.found).To(BeFalse())
+			Expect(s.C.null).To(BeFalse())
+		})
+
+		It("records presence through a pointer", func() {
+			var s struct {
+				A *recordsPresence
+				B *recordsPresence
+			}
+			unmarshal(&s, `{"A":{"name":"foo"}}`)
+			Expect(s.A.found).To(BeTrue())
+			Expect(s.B).To(BeNil())
+		})
+
+		It("records presence for list elements", func() {
+			var s struct{ A []recordsPresence }
+			unmarshal(&s, `{"A":[{"name":"foo"},null]}`)
+			Expect(s.A).To(HaveLen(2))
+			Expect(s.A[0].found).To(BeTrue())
+			Expect(s.A[0].null).To(BeFalse())
+			Expect(s.A[1].found).To(BeTrue())
+			Expect(s.A[1].null).To(BeTrue())
+		})
+	})
+
+	Describe("config", func() {
+		When("AllowIntegralFloats is set", func() {
+			config := jsonry.Config{AllowIntegralFloats: true}
+
+			It("accepts integral floats for int and uint fields", func() {
+				var s struct {
+					I int
+					J int8
+					U uint
+					P *uint16
+				}
+				Expect(config.Unmarshal([]byte(`{"I":3.0,"J":-1.28e2,"U":4e2,"P":6.5e4}`), &s)).To(Succeed())
+				Expect(s.I).To(Equal(3))
+				Expect(s.J).To(Equal(int8(-128)))
+				Expect(s.U).To(Equal(uint(400)))
+				Expect(s.P).To(PointTo(Equal(uint16(65000))))
+			})
+
+			It("rejects floats with a fractional part", func() {
+				var s struct{ I int }
+				err := config.Unmarshal([]byte(`{"I":3.5}`), &s)
+				Expect(err).To(MatchError(`cannot unmarshal "3.5" type "number" into field "I" (type "int")`))
+			})
+
+			It("rejects integral floats that are out of range", func() {
+				var s struct {
+					I int8
+					J int64
+					U uint
+				}
+				err := config.Unmarshal([]byte(`{"I":1.28e2}`), &s)
+				Expect(err).To(MatchError(`number "1.28e2" is out of range for field "I" (type "int8")`))
+
+				err = config.Unmarshal([]byte(`{"J":1e19}`), &s)
+				Expect(err).To(MatchError(`number "1e19" is out of range for field "J" (type "int64")`))
+
+				err = config.Unmarshal([]byte(`{"U":-1.0}`), &s)
+				Expect(err).To(MatchError(`number "-1.0" is out of range for field "U" (type "uint")`))
+			})
+		})
+
+		When("Tags is set", func() {
+			It("reads the tags in the specified order", func() {
+				var s struct {
+					A string `json:"a" jsonry:"b.c"`
+					B string `cf:"d.e"`
+				}
+				config := jsonry.Config{Tags: []string{"cf", "jsonry"}}
+				Expect(config.Unmarshal([]byte(`{"a":"no","b":{"c":"foo"},"d":{"e":"bar"}}`), &s)).To(Succeed())
+				Expect(s.A).To(Equal("foo"))
+				Expect(s.B).To(Equal("bar"))
+			})
+		})
+
+		When("ComplexEncoding is set", func() {
+			It("unmarshals complex numbers from arrays", func() {
+				var s struct {
+					C complex64
+					D *complex128 `jsonry:"d.e"`
+					E complex128
+				}
+				config := jsonry.Config{ComplexEncoding: jsonry.ComplexArray}
+				Expect(config.Unmarshal([]byte(`{"C":[1.5,-2],"d":{"e":[3,4e-10]},"E":null}`), &s)).To(Succeed())
+				Expect(s.C).To(Equal(complex64(complex(1.5, -2))))
+				Expect(s.D).To(PointTo(Equal(complex(3, 4e-10))))
+				Expect(s.E).To(BeZero())
+
+				err := config.Unmarshal([]byte(`{"C":[1,2,3]}`), &s)
+				Expect(err).To(MatchError(`cannot unmarshal "[1 2 3]" type "[]interface {}" into field "C" (type "complex64")`))
+
+				err = config.Unmarshal([]byte(`{"C":{"real":1,"imag":2}}`), &s)
+				Expect(err).To(MatchError(`cannot unmarshal "map[imag:2 real:1]" type "map[string]interface {}" into field "C" (type "complex64")`))
+
+				err = config.Unmarshal([]byte(`{"C":[1e39,0]}`), &s)
+				Expect(err).To(MatchError(`number "1e39" is out of range for field "C" (type "complex64")`))
+			})
+
+			It("unmarshals complex numbers from objects", func() {
+				var s struct{ C []complex128 }
+				config := jsonry.Config{ComplexEncoding: jsonry.ComplexObject}
+				Expect(config.Unmarshal([]byte(`{"C":[{"real":1,"imag":2},{"imag":-1}]}`), &s)).To(Succeed())
+				Expect(s.C).To(Equal([]complex128{complex(1, 2), complex(0, -1)}))
+
+				err := config.Unmarshal([]byte(`{"C":[{"real":"one"}]}`), &s)
+				Expect(err).To(MatchError(`cannot unmarshal "map[real:one]" type "map[string]interface {}" into index 0 (type "complex128") path C[0]`))
+			})
+
+			It("round trips complex numbers", func() {
+				type t struct {
+					C64  complex64
+					C128 complex128 `jsonry:"a.b"`
+				}
+				for _, encoding := range []jsonry.ComplexEncoding{jsonry.ComplexArray, jsonry.ComplexObject} {
+					config := jsonry.Config{ComplexEncoding: encoding}
+					in := t{C64: complex(1.1, 2.2), C128: complex(-3.3, 4.4)}
+					data, err := config.Marshal(in)
+					Expect(err).NotTo(HaveOccurred())
+
+					var out t
+					Expect(config.Unmarshal(data, &out)).To(Succeed())
+					Expect(out).To(Equal(in))
+				}
+			})
+		})
+
+		When("Merge is set", func() {
+			type space struct {
+				Name string
+				GUID string
+			}
+			config := jsonry.Config{Merge: true}
+
+			It("reuses existing pointers", func() {
+				name := "old"
+				sp := &space{Name: "old", GUID: "guid"}
+				s := struct {
+					N *string
+					S *space `jsonry:"a.space"`
+				}{N: &name, S: sp}
+
+				Expect(config.Unmarshal([]byte(`{"N":"new","a":{"space":{"Name":"new"}}}`), &s)).To(Succeed())
+				Expect(s.N).To(BeIdenticalTo(&name))
+				Expect(name).To(Equal("new"))
+				Expect(s.S).To(BeIdenticalTo(sp))
+				Expect(*sp).To(Equal(space{Name: "new", GUID: "guid"}))
+			})
+
+			It("allocates nil pointers", func() {
+				var s struct{ S *space }
+				Expect(config.Unmarshal([]byte(`{"S":{"Name":"new"}}`), &s)).To(Succeed())
+				Expect(s.S).To(PointTo(Equal(space{Name: "new"})))
+			})
+
+			It("merges map entries", func() {
+				s := struct {
+					M map[string]space
+					P *map[string]int
+					N map[string]int
+				}{
+					M: map[string]space{"a": {Name: "alpha", GUID: "1"}, "b": {Name: "beta"}},
+					P: &map[string]int{"x": 1},
+				}
+
+				Expect(config.Unmarshal([]byte(`{"M":{"a":{"Name":"new"},"c":{"GUID":"3"}},"P":{"y":2},"N":{"z":3}}`), &s)).To(Succeed())
+				Expect(s.M).To(Equal(map[string]space{
+					"a": {Name: "new", GUID: "1"},
+					"b": {Name: "beta"},
+					"c": {GUID: "3"},
+				}))
+				Expect(s.P).To(PointTo(Equal(map[string]int{"x": 1, "y": 2})))
+				Expect(s.N).To(Equal(map[string]int{"z": 3}))
+			})
+
+			It("replaces slices", func() {
+				s := struct{ L []space }{L: []space{{Name: "a", GUID: "1"}, {Name: "b"}}}
+				Expect(config.Unmarshal([]byte(`{"L":[{"Name":"c"}]}`), &s)).To(Succeed())
+				Expect(s.L).To(Equal([]space{{Name: "c"}}))
+			})
+
+			It("replaces by default", func() {
+				sp := &space{Name: "old", GUID: "guid"}
+				s := struct {
+					S *space
+					M map[string]int
+				}{S: sp, M: map[string]int{"x": 1}}
+
+				unmarshal(&s, `{"S":{"Name":"new"},"M":{"y":2}}`)
+				Expect(s.S).To(PointTo(Equal(space{Name: "new"})))
+				Expect(*sp).To(Equal(space{Name: "old", GUID: "guid"}))
+				Expect(s.M).To(Equal(map[string]int{"y": 2}))
+			})
+		})
+
+		When("UseNumber is set", func() {
+			It("unmarshals numbers into interface{} fields as json.Number", func() {
+				var s struct{ I, F, L interface{} }
+				Expect(jsonry.Config{UseNumber: true}.Unmarshal([]byte(`{"I":42,"F":4.2,"L":[1]}`), &s)).To(Succeed())
+				Expect(s.I).To(Equal(json.Number("42")))
+				Expect(s.F).To(Equal(json.Number("4.2")))
+				Expect(s.L).To(Equal([]interface{}{json.Number("1")}))
+			})
+		})
+
+		When("Lenient is set", func() {
+			var coercions []jsonry.Coercion
+			config := jsonry.Config{
+				Lenient: true,
+				OnCoercion: func(c jsonry.Coercion) {
+					coercions = append(coercions, c)
+				},
+			}
+
+			BeforeEach(func() {
+				coercions = nil
+			})
+
+			It("coerces strings into bools and numbers", func() {
+				var s struct {
+					B bool
+					I int
+					U *uint8
+					F float64
+				}
+				Expect(config.Unmarshal([]byte(`{"B":"true","I":"-42","U":"7","F":"4.2e1"}`), &s)).To(Succeed())
+				Expect(s.B).To(BeTrue())
+				Expect(s.I).To(Equal(-42))
+				Expect(s.U).To(PointTo(Equal(uint8(7))))
+				Expect(s.F).To(Equal(42.0))
+				Expect(coercions).To(ConsistOf(
+					jsonry.Coercion{Value: "true", Type: reflect.TypeOf(true), Path: "B"},
+					jsonry.Coercion{Value: "-42", Type: reflect.TypeOf(0), Path: "I"},
+					jsonry.Coercion{Value: "7", Type: reflect.TypeOf(uint8(0)), Path: "U"},
+					jsonry.Coercion{Value: "4.2e1", Type: reflect.TypeOf(0.0), Path: "F"},
+				))
+			})
+
+			It("coerces numbers into strings", func() {
+				var s struct{ S []string }
+				Expect(config.Unmarshal([]byte(`{"S":[1.50,"two",3]}`), &s)).To(Succeed())
+				Expect(s.S).To(Equal([]string{"1.50", "two", "3"}))
+				Expect(coercions).To(ConsistOf(
+					jsonry.Coercion{Value: json.Number("1.50"), Type: reflect.TypeOf(""), Path: "S[0]"},
+					jsonry.Coercion{Value: json.Number("3"), Type: reflect.TypeOf(""), Path: "S[2]"},
+				))
+			})
+
+			It("coerces a single value into a slice", func() {
+				type t struct{ N string }
+				var s struct {
+					S []string
+					T []t `jsonry:"a.b"`
+				}
+				Expect(config.Unmarshal([]byte(`{"S":"one","a":{"b":{"N":"two"}}}`), &s)).To(Succeed())
+				Expect(s.S).To(Equal([]string{"one"}))
+				Expect(s.T).To(Equal([]t{{N: "two"}}))
+				Expect(coercions).To(ConsistOf(
+					jsonry.Coercion{Value: "one", Type: reflect.TypeOf([]string{}), Path: "S"},
+					jsonry.Coercion{Value: map[string]interface{}{"N": "two"}, Type: reflect.TypeOf([]t{}), Path: "T"},
+				))
+			})
+
+			It("reports the path of nested values", func() {
+				type inner struct{ I int }
+				var s struct {
+					L []map[string]inner `jsonry:"a.l"`
+				}
+				Expect(config.Unmarshal([]byte(`{"a":{"l":[{"k":{"I":"1"}}]}}`), &s)).To(Succeed())
+				Expect(coercions).To(ConsistOf(
+					jsonry.Coercion{Value: "1", Type: reflect.TypeOf(0), Path: `L[0]["k"].I`},
+				))
+
+				coercions = nil
+				var i int
+				Expect(config.Unmarshal([]byte(`"2"`), &i)).To(Succeed())
+				Expect(coercions).To(ConsistOf(jsonry.Coercion{Value: "2", Type: reflect.TypeOf(0)}))
+			})
+
+			It("still checks that numbers are in range", func() {
+				var s struct{ I int8 }
+				err := config.Unmarshal([]byte(`{"I":"1000"}`), &s)
+				Expect(err).To(MatchError(`string "1000" is out of range for field "I" (type "int8")`))
+				Expect(coercions).To(BeEmpty())
+			})
+
+			It("reports the string from the JSON when a coerced number cannot be set", func() {
+				var s struct{ I int }
+				err := config.Unmarshal([]byte(`{"I":"1.5"}`), &s)
+				Expect(err).To(MatchError(`cannot unmarshal "1.5" type "string" into field "I" (type "int")`))
+				Expect(coercions).To(BeEmpty())
+			})
+
+			It("rejects strings that cannot be coerced", func() {
+				var s struct {
+					B bool
+					I int
+				}
+				err := config.Unmarshal([]byte(`{"B":"yes"}`), &s)
+				Expect(err).To(MatchError(`cannot unmarshal "yes" type "string" into field "B" (type "bool")`))
+
+				err = config.Unmarshal([]byte(`{"I":"42 "}`), &s)
+				Expect(err).To(MatchError(`cannot unmarshal "42 " type "string" into field "I" (type "int")`))
+				Expect(coercions).To(BeEmpty())
+			})
+
+			It("rejects numbers with whitespace on either side", func() {
+				var s struct{ F float64 }
+				for _, v := range []string{` 42`, `42 `, `\t42`, `42\n`} {
+					err := config.Unmarshal([]byte(fmt.Sprintf(`{"F":"%s"}`, v)), &s)
+					Expect(err).To(MatchError(ContainSubstring(`into field "F" (type "float64")`)), v)
+				}
+				Expect(coercions).To(BeEmpty())
+			})
+
+			It(`only coerces "true" and "false" into bools`, func() {
+				var s struct{ B bool }
+				for _, v := range []string{`1`, `0`, `t`, `F`, `TRUE`, `False`} {
+					err := config.Unmarshal([]byte(fmt.Sprintf(`{"B":"%s"}`, v)), &s)
+					Expect(err).To(MatchError(fmt.Sprintf(`cannot unmarshal "%s" type "string" into field "B" (type "bool")`, v)))
+				}
+				Expect(coercions).To(BeEmpty())
+
+				Expect(config.Unmarshal([]byte(`{"B":"false"}`), &s)).To(Succeed())
+				Expect(s.B).To(BeFalse())
+				Expect(coercions).To(ConsistOf(jsonry.Coercion{Value: "false", Type: reflect.TypeOf(true), Path: "B"}))
+			})
+
+			It("does not report a single value as a slice when its element cannot be set", func() {
+				var s struct{ L []int }
+				err := config.Unmarshal([]byte(`{"L":"x"}`), &s)
+				Expect(err).To(MatchError(ContainSubstring(`cannot unmarshal "x" type "string"`)))
+				Expect(coercions).To(BeEmpty())
+			})
+
+			It("works without a callback", func() {
+				var s struct{ I int }
+				Expect(jsonry.Config{Lenient: true}.Unmarshal([]byte(`{"I":"42"}`), &s)).To(Succeed())
+				Expect(s.I).To(Equal(42))
+			})
+		})
+	})
+
 	Describe("receiver", func() {
 		It("accept a struct pointer", func() {
 			var s struct{}
@@ -536,13 +1226,155 @@ var _ = Describe("Unmarshal", func() {
 		It("rejects a struct", func() {
 			var s struct{}
 			err := jsonry.Unmarshal([]byte(`{}`), s)
-			Expect(err).To(MatchError("receiver must be a pointer to a struct, got a non-pointer"))
+			Expect(err).To(MatchError("receiver must be a pointer, got a non-pointer"))
 		})
 
-		It("rejects a pointer to a non-struct", func() {
-			var s int
-			err := jsonry.Unmarshal([]byte(`{}`), &s)
-			Expect(err).To(MatchError("receiver must be a pointer to a struct type, got: int"))
+		It("rejects a nil pointer", func() {
+			var s *struct{}
+			err := jsonry.Unmarshal([]byte(`{}`), s)
+			Expect(err).To(MatchError("receiver must not be a nil pointer, got: *struct {}"))
+		})
+
+		It("accepts a pointer to a slice of structs", func() {
+			type app struct {
+				Name      string `jsonry:"name"`
+				SpaceGUID string `jsonry:"relationships.space.data.guid"`
+			}
+
+			var s []app
+			unmarshal(&s, `[{"name":"a","relationships":{"space":{"data":{"guid":"1"}}}},{"name":"b"}]`)
+			Expect(s).To(Equal([]app{{Name: "a", SpaceGUID: "1"}, {Name: "b"}}))
+
+			var p []*app
+			unmarshal(&p, `[{"name":"a"},null]`)
+			Expect(p).To(Equal([]*app{{Name: "a"}, nil}))
+		})
+
+		It("accepts a pointer to a map of structs", func() {
+			type space struct {
+				OrgGUID string `jsonry:"relationships.org.data.guid"`
+			}
+
+			var s map[string]space
+			unmarshal(&s, `{"s":{"relationships":{"org":{"data":{"guid":"o"}}}}}`)
+			Expect(s).To(Equal(map[string]space{"s": {OrgGUID: "o"}}))
+		})
+
+		It("accepts a pointer to a basic type", func() {
+			var i int
+			unmarshal(&i, `42`)
+			Expect(i).To(Equal(42))
+
+			var v interface{}
+			unmarshal(&v, `[true,"a"]`)
+			Expect(v).To(Equal([]interface{}{true, "a"}))
+
+			var p *string
+			unmarshal(&p, `"hello"`)
+			Expect(p).To(PointTo(Equal("hello")))
+		})
+
+		It("reports errors with the index", func() {
+			var s []struct{ I int }
+			expectToFail(&s, `[{"I":1},{"I":"x"}]`, `cannot unmarshal "x" type "string" into field "I" (type "int") path [1].I`)
+
+			var i int
+			expectToFail(&i, `{}`, `cannot unmarshal "map[]" type "map[string]interface {}" into root path`)
+		})
+	})
+
+	Describe("UnmarshalAs", func() {
+		type space struct {
+			Name string `jsonry:"data.name"`
+			GUID string `jsonry:"data.guid"`
+		}
+
+		It("returns a struct", func() {
+			s, err := jsonry.UnmarshalAs[space]([]byte(`{"data":{"name":"foo","guid":"bar"}}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s).To(Equal(space{Name: "foo", GUID: "bar"}))
+		})
+
+		It("returns a pointer to a struct", func() {
+			s, err := jsonry.UnmarshalAs[*space]([]byte(`{"data":{"name":"foo","guid":"bar"}}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s).To(PointTo(Equal(space{Name: "foo", GUID: "bar"})))
+		})
+
+		It("returns errors", func() {
+			_, err := jsonry.UnmarshalAs[space]([]byte(`{"data":{"name":42}}`))
+			Expect(err).To(MatchError(`cannot unmarshal "42" type "number" into field "Name" (type "string")`))
+
+			p, err := jsonry.UnmarshalAs[*space]([]byte(`{"data":{"name":42}}`))
+			Expect(err).To(MatchError(`cannot unmarshal "42" type "number" into field "Name" (type "string")`))
+			Expect(p).To(BeNil())
+		})
+
+		It("returns other types", func() {
+			s, err := jsonry.UnmarshalAs[[]space]([]byte(`[{"data":{"name":"foo"}}]`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s).To(Equal([]space{{Name: "foo"}}))
+
+			i, err := jsonry.UnmarshalAs[*int]([]byte(`42`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(i).To(PointTo(Equal(42)))
+		})
+
+		It("can use a Config", func() {
+			s, err := jsonry.UnmarshalAsWithConfig[struct{ I int }](jsonry.Config{Lenient: true}, []byte(`{"I":"42"}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s.I).To(Equal(42))
+		})
+	})
+
+	Describe("UnmarshalAt", func() {
+		type app struct {
+			Name      string `jsonry:"name"`
+			SpaceGUID string `jsonry:"relationships.space.data.guid"`
+		}
+
+		It("reads the receiver from the path", func() {
+			var apps []app
+			data := `{"resources":[{"name":"a","relationships":{"space":{"data":{"guid":"s"}}}},{"name":"b"}],"pagination":{}}`
+			Expect(jsonry.UnmarshalAt([]byte(data), "resources", &apps)).To(Succeed())
+			Expect(apps).To(Equal([]app{{Name: "a", SpaceGUID: "s"}, {Name: "b"}}))
+
+			var a app
+			Expect(jsonry.UnmarshalAt([]byte(`{"data":{"attributes":{"name":"c"}}}`), "data.attributes", &a)).To(Succeed())
+			Expect(a).To(Equal(app{Name: "c"}))
+		})
+
+		It("collects the values when the path has a list hint", func() {
+			var guids []string
+			Expect(jsonry.UnmarshalAt([]byte(`{"resources":[{"guid":"a"},{"guid":"b"}]}`), "resources[].guid", &guids)).To(Succeed())
+			Expect(guids).To(Equal([]string{"a", "b"}))
+		})
+
+		It("does not modify the receiver when the path is not there", func() {
+			a := app{Name: "kept"}
+			Expect(jsonry.UnmarshalAt([]byte(`{"data":{}}`), "data.attributes", &a)).To(Succeed())
+			Expect(jsonry.UnmarshalAt([]byte(`null`), "data", &a)).To(Succeed())
+			Expect(a).To(Equal(app{Name: "kept"}))
+		})
+
+		It("is the same as Unmarshal() for an empty path", func() {
+			var a app
+			Expect(jsonry.UnmarshalAt([]byte(`{"name":"a"}`), "", &a)).To(Succeed())
+			Expect(a).To(Equal(app{Name: "a"}))
+		})
+
+		It("uses the Config", func() {
+			var a struct{ I int }
+			Expect(jsonry.Config{Lenient: true}.UnmarshalAt([]byte(`{"data":{"I":"42"}}`), "data", &a)).To(Succeed())
+			Expect(a.I).To(Equal(42))
+		})
+
+		It("returns errors", func() {
+			var a app
+			Expect(jsonry.UnmarshalAt([]byte(`{"data":{"name":42}}`), "data", &a)).To(MatchError(`cannot unmarshal "42" type "number" into field "Name" (type "string")`))
+			Expect(jsonry.UnmarshalAt([]byte(`[]`), "data", &a)).To(MatchError(`cannot find path "data" in JSON which is not an object`))
+			Expect(jsonry.UnmarshalAt([]byte(`{`), "data", &a)).To(MatchError("error parsing JSON: unexpected EOF"))
+			Expect(jsonry.UnmarshalAt([]byte(`{}`), "data", a)).To(MatchError("receiver must be a pointer, got a non-pointer"))
 		})
 	})
 })